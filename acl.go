@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// TopicACL grants or denies a principal an operation on the topic it is declared on
+type TopicACL struct {
+	// Principal is the Kafka principal, e.g. User:orders-service
+	Principal string `yaml:"principal"`
+
+	// Operation is a Kafka ACL operation such as read, write, describe or all
+	Operation string `yaml:"operation"`
+
+	// Permission is allow (the default) or deny
+	Permission string `yaml:"permission,omitempty"`
+
+	// Host restricts the binding to connections from one host (default: any host)
+	Host string `yaml:"host,omitempty"`
+}
+
+// topicACLOperations lists the operations that apply to topic resources
+var topicACLOperations = []string{"ALL", "READ", "WRITE", "CREATE", "DELETE", "ALTER", "DESCRIBE", "DESCRIBE_CONFIGS", "ALTER_CONFIGS"}
+
+// topicACLBindings converts a topic's acls block into literal topic ACL bindings
+func topicACLBindings(topic TopicConfig) ([]kafka.ACLBinding, error) {
+	bindings := make([]kafka.ACLBinding, 0, len(topic.ACLs))
+	for i, acl := range topic.ACLs {
+		if kind, name, ok := strings.Cut(acl.Principal, ":"); !ok || kind == "" || name == "" {
+			return nil, fmt.Errorf("topic '%s' acl %d has invalid principal '%s' (expected Type:name, e.g. User:orders-service)",
+				topic.Name, i+1, acl.Principal)
+		}
+
+		operationName := strings.ToUpper(strings.ReplaceAll(acl.Operation, "-", "_"))
+		if !slices.Contains(topicACLOperations, operationName) {
+			return nil, fmt.Errorf("topic '%s' acl %d has invalid operation '%s' (expected one of %s)",
+				topic.Name, i+1, acl.Operation, strings.ToLower(strings.Join(topicACLOperations, ", ")))
+		}
+		operation, err := kafka.ACLOperationFromString(operationName)
+		if err != nil {
+			return nil, fmt.Errorf("topic '%s' acl %d has invalid operation '%s': %w", topic.Name, i+1, acl.Operation, err)
+		}
+
+		permissionName := acl.Permission
+		if permissionName == "" {
+			permissionName = "allow"
+		}
+		permission, err := kafka.ACLPermissionTypeFromString(permissionName)
+		if err != nil || permission == kafka.ACLPermissionTypeAny {
+			return nil, fmt.Errorf("topic '%s' acl %d has invalid permission '%s' (expected allow or deny)",
+				topic.Name, i+1, acl.Permission)
+		}
+
+		host := acl.Host
+		if host == "" {
+			host = "*"
+		}
+
+		bindings = append(bindings, kafka.ACLBinding{
+			Type:                kafka.ResourceTopic,
+			Name:                topic.Name,
+			ResourcePatternType: kafka.ResourcePatternTypeLiteral,
+			Principal:           acl.Principal,
+			Host:                host,
+			Operation:           operation,
+			PermissionType:      permission,
+		})
+	}
+
+	return bindings, nil
+}
+
+// GetTopicACLs returns the ACL bindings declared in the acls blocks of the topics the
+// parsed config defines for the environment
+func GetTopicACLs(config TopicsConfig, environment string) ([]kafka.ACLBinding, error) {
+	topics, err := resolveEnvironmentTopics(config, environment)
+	if err != nil {
+		return nil, err
+	}
+
+	var bindings []kafka.ACLBinding
+	for _, topic := range topics {
+		topicBindings, err := topicACLBindings(topic)
+		if err != nil {
+			return nil, err
+		}
+		bindings = append(bindings, topicBindings...)
+	}
+
+	return bindings, nil
+}
+
+// describeACL formats a binding for progress lines, e.g. "allow write on topic
+// 'orders' for User:orders-service"
+func describeACL(binding kafka.ACLBinding) string {
+	return fmt.Sprintf("%s %s on topic '%s' for %s",
+		strings.ToLower(binding.PermissionType.String()), strings.ToLower(binding.Operation.String()),
+		binding.Name, binding.Principal)
+}
+
+// CreateACLs applies the ACL bindings of the given topics in a single request, also
+// for topics that already existed; creating a binding that exists is not an error.
+// It returns the number of bindings applied, with per-binding failures reported
+// through a TopicErrors keyed by topic.
+func (tm *TopicManager) CreateACLs(ctx context.Context, bindings []kafka.ACLBinding) (int, error) {
+	if len(bindings) == 0 {
+		return 0, nil
+	}
+	fmt.Fprintf(messageOut, "🔐 Applying %d ACLs...\n", len(bindings))
+
+	results, err := tm.adminClient.CreateACLs(ctx, bindings)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create ACLs: %w", err)
+	}
+
+	applied := 0
+	failures := make(TopicErrors)
+	progress := newProgressReporter("acl", len(bindings), tm.opts)
+	for i, result := range results {
+		binding := bindings[i]
+		if result.Error.Code() != kafka.ErrNoError {
+			progress.failed(binding.Name, result.Error,
+				fmt.Sprintf("❌ Failed to apply %s: %v", describeACL(binding), result.Error))
+			failures[binding.Name] = result.Error
+			continue
+		}
+		progress.succeeded(binding.Name, "granted", fmt.Sprintf("🔐 Applied %s", describeACL(binding)))
+		applied++
+	}
+
+	if len(failures) > 0 {
+		return applied, failures
+	}
+	return applied, nil
+}
+
+// aclsForTopics returns the ACL bindings of the given topics, without those of the
+// topics that failed
+func aclsForTopics(bindings []kafka.ACLBinding, topicSpecs []kafka.TopicSpecification, failedTopics []string) []kafka.ACLBinding {
+	var selected []kafka.ACLBinding
+	for _, binding := range bindings {
+		configured := slices.ContainsFunc(topicSpecs, func(spec kafka.TopicSpecification) bool {
+			return spec.Topic == binding.Name
+		})
+		if configured && !slices.Contains(failedTopics, binding.Name) {
+			selected = append(selected, binding)
+		}
+	}
+	return selected
+}
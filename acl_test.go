@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+func TestTopicACLBindings(t *testing.T) {
+	cases := []struct {
+		name string
+		acl  TopicACL
+		want kafka.ACLBinding // Type, Name and ResourcePatternType are checked for every case
+		err  string           // substring of the expected error, empty when the acl is valid
+	}{
+		{
+			name: "defaults",
+			acl:  TopicACL{Principal: "User:orders-service", Operation: "write"},
+			want: kafka.ACLBinding{Principal: "User:orders-service", Host: "*",
+				Operation: kafka.ACLOperationWrite, PermissionType: kafka.ACLPermissionTypeAllow},
+		},
+		{
+			name: "deny from one host",
+			acl:  TopicACL{Principal: "User:batch", Operation: "READ", Permission: "deny", Host: "10.0.0.7"},
+			want: kafka.ACLBinding{Principal: "User:batch", Host: "10.0.0.7",
+				Operation: kafka.ACLOperationRead, PermissionType: kafka.ACLPermissionTypeDeny},
+		},
+		{
+			name: "dashed operation",
+			acl:  TopicACL{Principal: "Group:ops", Operation: "describe-configs"},
+			want: kafka.ACLBinding{Principal: "Group:ops", Host: "*",
+				Operation: kafka.ACLOperationDescribeConfigs, PermissionType: kafka.ACLPermissionTypeAllow},
+		},
+		{
+			name: "principal without type",
+			acl:  TopicACL{Principal: "orders-service", Operation: "write"},
+			err:  "invalid principal 'orders-service'",
+		},
+		{
+			name: "principal without name",
+			acl:  TopicACL{Principal: "User:", Operation: "write"},
+			err:  "invalid principal 'User:'",
+		},
+		{
+			name: "operation not on topics",
+			acl:  TopicACL{Principal: "User:orders-service", Operation: "cluster-action"},
+			err:  "invalid operation 'cluster-action'",
+		},
+		{
+			name: "unknown permission",
+			acl:  TopicACL{Principal: "User:orders-service", Operation: "write", Permission: "maybe"},
+			err:  "invalid permission 'maybe'",
+		},
+		{
+			name: "any permission",
+			acl:  TopicACL{Principal: "User:orders-service", Operation: "write", Permission: "any"},
+			err:  "invalid permission 'any'",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			bindings, err := topicACLBindings(TopicConfig{Name: "orders", ACLs: []TopicACL{tc.acl}})
+			switch {
+			case tc.err != "" && err == nil:
+				t.Fatalf("topicACLBindings succeeded, want an error containing %q", tc.err)
+			case tc.err != "" && !strings.Contains(err.Error(), tc.err):
+				t.Fatalf("topicACLBindings error %q, want it to contain %q", err, tc.err)
+			case tc.err != "":
+				return
+			case err != nil:
+				t.Fatalf("topicACLBindings failed: %v", err)
+			}
+
+			want := tc.want
+			want.Type, want.Name, want.ResourcePatternType = kafka.ResourceTopic, "orders", kafka.ResourcePatternTypeLiteral
+			if len(bindings) != 1 || bindings[0] != want {
+				t.Errorf("topicACLBindings = %+v, want [%+v]", bindings, want)
+			}
+		})
+	}
+}
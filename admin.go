@@ -2,36 +2,61 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"regexp"
 	"strings"
 
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 )
 
+// statusOut receives connection diagnostics and the warnings printed while the config
+// file loads; commands that print machine-readable data on stdout point it at stderr
+// instead
+var statusOut io.Writer = os.Stdout
+
 // getKafkaAdmin creates a new Kafka admin client from the provided configuration
 func getKafkaAdmin(config KafkaConfig) (*kafka.AdminClient, error) {
-	fmt.Printf("🔧 Creating Kafka admin client with config:\n")
-	fmt.Printf("   Server: %s\n", config.Server)
+	fmt.Fprintf(statusOut, "🔧 Creating Kafka admin client with config:\n")
+	fmt.Fprintf(statusOut, "   Server: %s\n", config.Server)
+	fmt.Fprintf(statusOut, "   Client ID: %s\n", config.ClientID)
 
 	// Create admin client configuration
 	configMap := &kafka.ConfigMap{
 		"bootstrap.servers":       config.Server,
-		"socket.keepalive.enable": true,
+		"client.id":               config.ClientID, // Identifies this tool in broker request logs
+		"socket.keepalive.enable": config.SocketKeepalive,
 		"request.timeout.ms":      5000,  // 5 second timeout for requests
 		"metadata.max.age.ms":     30000, // Cache metadata for 30 seconds
+
+		// The admin client is a producer internally, which would otherwise let metadata
+		// requests for missing topics auto-create them on permissive brokers
+		"allow.auto.create.topics": false,
+
+		"broker.address.family": config.BrokerAddressFamily,
+	}
+	if config.BrokerAddressFamily != "any" {
+		fmt.Fprintf(statusOut, "   Broker address family: %s\n", config.BrokerAddressFamily)
+	}
+	if config.ConnectionsMaxIdleMs > 0 {
+		configMap.SetKey("connections.max.idle.ms", config.ConnectionsMaxIdleMs)
+		fmt.Fprintf(statusOut, "   Connections max idle: %dms\n", config.ConnectionsMaxIdleMs)
+	}
+	if config.SocketTimeoutMs > 0 {
+		configMap.SetKey("socket.timeout.ms", config.SocketTimeoutMs)
+		fmt.Fprintf(statusOut, "   Socket timeout: %dms\n", config.SocketTimeoutMs)
+	}
+	if !config.SocketKeepalive {
+		fmt.Fprintf(statusOut, "   Socket keepalive: disabled\n")
 	}
 
-	// Add debug configuration if enabled
+	// KAFKA_LOG_LEVEL applies with and without debugging
+	configMap.SetKey("log_level", config.LogLevel)
 	if config.DebugEnabled {
-		if config.Debug != "" {
-			configMap.SetKey("debug", config.Debug)
-		} else {
-			configMap.SetKey("debug", "broker,topic,protocol") // Default debug categories
-		}
-		configMap.SetKey("log_level", config.LogLevel)
-		fmt.Printf("   Debug: %s (level %d)\n", config.Debug, config.LogLevel)
+		configMap.SetKey("debug", config.DebugCategories())
+		fmt.Fprintf(statusOut, "   Debug: %s (log level %d)\n", config.DebugCategories(), config.LogLevel)
 	} else {
-		configMap.SetKey("log_level", 3) // INFO level for production
-		fmt.Printf("   Debug: Disabled (log level 3)\n")
+		fmt.Fprintf(statusOut, "   Debug: Disabled (log level %d)\n", config.LogLevel)
 	}
 
 	// Set security protocol and authentication
@@ -42,35 +67,93 @@ func getKafkaAdmin(config KafkaConfig) (*kafka.AdminClient, error) {
 		configMap.SetKey("sasl.password", config.Password)
 
 		// Set security protocol based on server type
-		if shouldUseSSL(config.Server) {
-			configMap.SetKey("security.protocol", "SASL_SSL")
-			fmt.Printf("   Authentication: SASL_SSL\n")
-		} else {
-			configMap.SetKey("security.protocol", "SASL_PLAINTEXT")
-			fmt.Printf("   Authentication: SASL_PLAINTEXT\n")
-		}
-		fmt.Printf("   Username: %s\n", config.Username)
+		protocol := securityProtocol(config)
+		configMap.SetKey("security.protocol", protocol)
+		fmt.Fprintf(statusOut, "   Authentication: %s\n", protocol)
+		fmt.Fprintf(statusOut, "   Username: %s\n", config.Username)
 	} else {
-		// Use PLAINTEXT for unauthenticated connections
-		configMap.SetKey("security.protocol", "PLAINTEXT")
-		fmt.Printf("   Authentication: None (PLAINTEXT)\n")
-		fmt.Printf("   ⚠️  WARNING: No authentication credentials provided!\n")
+		// Use PLAINTEXT for unauthenticated connections unless a protocol is forced
+		protocol := securityProtocol(config)
+		configMap.SetKey("security.protocol", protocol)
+		fmt.Fprintf(statusOut, "   Authentication: None (%s)\n", protocol)
+		fmt.Fprintf(statusOut, "   ⚠️  WARNING: No authentication credentials provided!\n")
+	}
+
+	// Apply extra properties last so they override the defaults above
+	extraEntries, err := config.ExtraConfigEntries()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range extraEntries {
+		if err := configMap.SetKey(entry[0], entry[1]); err != nil {
+			return nil, fmt.Errorf("failed to set extra config '%s': %w", entry[0], err)
+		}
+		fmt.Fprintf(statusOut, "   Extra config: %s\n", entry[0]) // value omitted, it may be a secret
 	}
 
 	// Create admin client
-	fmt.Printf("🔌 Connecting to Kafka cluster...\n")
+	fmt.Fprintf(statusOut, "🔌 Connecting to Kafka cluster...\n")
 	adminClient, err := kafka.NewAdminClient(configMap)
 	if err != nil {
+		if len(extraEntries) > 0 {
+			return nil, fmt.Errorf("failed to create admin client (check KAFKA_EXTRA_CONFIG): %w", err)
+		}
 		return nil, fmt.Errorf("failed to create admin client: %w", err)
 	}
 
 	return adminClient, nil
 }
 
+// securityProtocol returns the security.protocol the admin client uses for the config,
+// before any KAFKA_EXTRA_CONFIG override; KAFKA_SECURITY_PROTOCOL bypasses the inference
+func securityProtocol(config KafkaConfig) string {
+	if config.SecurityProtocol != "" {
+		return config.SecurityProtocol
+	}
+	if !config.ShouldUseAuth() {
+		return "PLAINTEXT"
+	}
+	if shouldUseSSL(config.Server) {
+		return "SASL_SSL"
+	}
+	return "SASL_PLAINTEXT"
+}
+
+// confluentAPIKey matches the shape of Confluent Cloud API keys, e.g. ABCDEFGH12345678
+var confluentAPIKey = regexp.MustCompile(`^[A-Z0-9]{16}$`)
+
+// isConfluentCloud reports whether the server is a Confluent Cloud endpoint
+func isConfluentCloud(server string) bool {
+	return strings.Contains(server, "confluent.cloud")
+}
+
+// checkConfluentCloud fails early when a Confluent Cloud endpoint is configured without
+// an API key and secret, which would otherwise be attempted as PLAINTEXT, and warns
+// when the credentials or protocol do not look like Confluent Cloud's
+func checkConfluentCloud(config KafkaConfig) error {
+	if !isConfluentCloud(config.Server) {
+		return nil
+	}
+
+	if config.Username == "" || config.Password == "" {
+		return fmt.Errorf("Confluent Cloud endpoint %s requires an API key and secret: "+
+			"set KAFKA_USERNAME to the API key and KAFKA_PASSWORD to the API secret", config.Server)
+	}
+	if !confluentAPIKey.MatchString(config.Username) {
+		fmt.Fprintf(statusOut, "⚠️  KAFKA_USERNAME does not look like a Confluent Cloud API key (16 uppercase letters and digits); "+
+			"check that the key and secret are not swapped\n")
+	}
+	if protocol := securityProtocol(config); protocol != "SASL_SSL" {
+		fmt.Fprintf(statusOut, "⚠️  Confluent Cloud requires SASL_SSL, but security.protocol is %s\n", protocol)
+	}
+
+	return nil
+}
+
 // shouldUseSSL determines if SSL should be used based on the server URL
 func shouldUseSSL(server string) bool {
 	// Use SSL for Confluent Cloud or servers with SSL-specific ports
-	return strings.Contains(server, "confluent.cloud") ||
+	return isConfluentCloud(server) ||
 		strings.Contains(server, ":9093") ||
 		strings.Contains(server, ":9094") ||
 		strings.Contains(server, ":9095")
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// authFailure is the kind of credential problem behind an error
+type authFailure int
+
+const (
+	noAuthFailure authFailure = iota
+	authenticationFailure
+	authorizationFailure
+)
+
+// classifyAuthFailure reports whether err was caused by rejected credentials or by
+// missing ACLs. Kafka error codes are checked where the error still carries them,
+// including per-topic TopicErrors; the message text covers errors formatted with %v.
+func classifyAuthFailure(err error) authFailure {
+	if err == nil {
+		return noAuthFailure
+	}
+
+	var codes []kafka.ErrorCode
+	var kafkaErr kafka.Error
+	if errors.As(err, &kafkaErr) {
+		codes = append(codes, kafkaErr.Code())
+	}
+	var topicErrs TopicErrors
+	if errors.As(err, &topicErrs) {
+		for _, topicErr := range topicErrs {
+			codes = append(codes, topicErr.Code())
+		}
+	}
+
+	for _, code := range codes {
+		switch code {
+		case kafka.ErrSaslAuthenticationFailed, kafka.ErrAuthentication:
+			return authenticationFailure
+		case kafka.ErrTopicAuthorizationFailed, kafka.ErrClusterAuthorizationFailed, kafka.ErrGroupAuthorizationFailed:
+			return authorizationFailure
+		}
+	}
+
+	message := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(message, "authentication fail") || strings.Contains(message, "sasl authentication error"):
+		return authenticationFailure
+	case strings.Contains(message, "authorization failed"):
+		return authorizationFailure
+	}
+	return noAuthFailure
+}
+
+// authExitCode returns exitAuthError after logging what to check when err is a
+// credential problem, and code otherwise
+func authExitCode(err error, code int) int {
+	switch classifyAuthFailure(err) {
+	case authenticationFailure:
+		log.Printf("🔐 Authentication failed: check KAFKA_USERNAME/KAFKA_PASSWORD and KAFKA_SECURITY_PROTOCOL")
+	case authorizationFailure:
+		log.Printf("🔐 Not authorized: the credentials were accepted but lack the ACLs for this operation (check topic and cluster ACLs)")
+	default:
+		return code
+	}
+	return exitAuthError
+}
@@ -0,0 +1,8 @@
+//go:build !cgo
+
+package main
+
+// confluent-kafka-go wraps the librdkafka C library, so without cgo its package is
+// empty and the build fails on undefined kafka identifiers. This declaration fails
+// first, with a name that explains the fix.
+type cgoRequired confluent_kafka_go_requires_cgo__build_with_CGO_ENABLED_1_and_a_C_compiler
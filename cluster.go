@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// clusterKafkaConfig derives the connection configuration of a declared cluster
+// from the environment-based configuration
+func clusterKafkaConfig(base KafkaConfig, cluster ClusterConfig) (KafkaConfig, error) {
+	config := base
+	config.Server = cluster.Server
+
+	if cluster.UsernameEnv != "" {
+		config.Username = os.Getenv(cluster.UsernameEnv)
+		if config.Username == "" {
+			return config, fmt.Errorf("cluster '%s': environment variable %s is not set", cluster.Name, cluster.UsernameEnv)
+		}
+	}
+	if cluster.PasswordEnv != "" {
+		config.Password = os.Getenv(cluster.PasswordEnv)
+		if config.Password == "" {
+			return config, fmt.Errorf("cluster '%s': environment variable %s is not set", cluster.Name, cluster.PasswordEnv)
+		}
+	}
+
+	return config, nil
+}
+
+// clusterRun records the outcome of syncing a single cluster
+type clusterRun struct {
+	name     string
+	result   SyncResult
+	exitCode int
+}
+
+// syncClusters runs the sync against each declared cluster sequentially, prints a
+// per-cluster summary and returns the counts added up over the clusters, with the
+// topics created on any cluster, together with the combined exit code
+func syncClusters(ctx context.Context, clusters []ClusterConfig, topicSpecs []kafka.TopicSpecification,
+	managerOptions ManagerOptions, strict, dryRun bool) (SyncResult, int) {
+	base, err := loadConfig()
+	if err != nil {
+		log.Printf("❌ Failed to load configuration: %v", err)
+		return SyncResult{}, exitConfigError
+	}
+
+	var runs []clusterRun
+	for _, cluster := range clusters {
+		if ctx.Err() != nil {
+			break
+		}
+
+		fmt.Fprintf(messageOut, "🌐 Cluster '%s' (%s)\n", cluster.Name, cluster.Server)
+		run := clusterRun{name: cluster.Name}
+
+		config, err := clusterKafkaConfig(base, cluster)
+		if err == nil {
+			err = checkConfluentCloud(config)
+		}
+		if err != nil {
+			log.Printf("❌ %v", err)
+			run.exitCode = exitConfigError
+			runs = append(runs, run)
+			continue
+		}
+
+		adminClient, err := getKafkaAdmin(config)
+		if err != nil {
+			log.Printf("❌ Failed to create Kafka admin client for cluster '%s': %v", cluster.Name, err)
+			run.exitCode = exitConnectionError
+			runs = append(runs, run)
+			continue
+		}
+
+		var admin KafkaAdmin = adminClient
+		if dryRun {
+			fake, err := NewFakeAdminFromCluster(ctx, adminClient)
+			adminClient.Close()
+			if err != nil {
+				log.Printf("❌ Failed to copy cluster '%s' for the dry run: %v", cluster.Name, err)
+				run.exitCode = authExitCode(err, exitConnectionError)
+				runs = append(runs, run)
+				continue
+			}
+			admin = fake
+		}
+
+		run.result, run.exitCode = runSync(ctx, NewTopicManager(admin, managerOptions), topicSpecs, strict)
+		admin.Close()
+		runs = append(runs, run)
+	}
+
+	var total SyncResult
+	seen := make(map[string]bool)
+	summary := summaryWriter(managerOptions)
+	fmt.Fprintf(summary, "🌐 Cluster Summary:\n")
+	for _, run := range runs {
+		for _, topic := range run.result.CreatedTopics {
+			if !seen[topic] {
+				seen[topic] = true
+				total.CreatedTopics = append(total.CreatedTopics, topic)
+			}
+		}
+		total.Created += run.result.Created
+		total.Updated += run.result.Updated
+		total.Unchanged += run.result.Unchanged
+		total.Failed += run.result.Failed
+
+		status := "✅"
+		if run.exitCode != exitOK {
+			status = "❌"
+		}
+		fmt.Fprintf(summary, "   %s %-20s %d created, %d updated, %d unchanged, %d failed (exit %d)\n",
+			status, run.name, run.result.Created, run.result.Updated, run.result.Unchanged, run.result.Failed, run.exitCode)
+	}
+
+	return total, combinedExitCode(runs)
+}
+
+// combinedExitCode is exitOK when every cluster succeeded, exitPartialFailure when
+// only some did, and otherwise the shared failure code (or exitFailure when mixed)
+func combinedExitCode(runs []clusterRun) int {
+	succeeded := 0
+	failureCode := exitOK
+	for _, run := range runs {
+		switch {
+		case run.exitCode == exitOK:
+			succeeded++
+		case failureCode == exitOK:
+			failureCode = run.exitCode
+		case failureCode != run.exitCode:
+			failureCode = exitFailure
+		}
+	}
+
+	switch {
+	case succeeded == len(runs):
+		return exitOK
+	case succeeded > 0:
+		return exitPartialFailure
+	default:
+		return failureCode
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestCombinedExitCode(t *testing.T) {
+	cases := []struct {
+		name  string
+		codes []int
+		want  int
+	}{
+		{"every cluster succeeded", []int{exitOK, exitOK}, exitOK},
+		{"some clusters failed", []int{exitOK, exitConnectionError}, exitPartialFailure},
+		{"a cluster partially failed", []int{exitOK, exitPartialFailure}, exitPartialFailure},
+		{"every cluster failed alike", []int{exitAuthError, exitAuthError}, exitAuthError},
+		{"every cluster failed differently", []int{exitConnectionError, exitAuthError}, exitFailure},
+		{"single failed cluster", []int{exitConnectionError}, exitConnectionError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			runs := make([]clusterRun, len(tc.codes))
+			for i, code := range tc.codes {
+				runs[i] = clusterRun{name: "cluster", exitCode: code}
+			}
+			if got := combinedExitCode(runs); got != tc.want {
+				t.Errorf("combinedExitCode(%v) = %d, want %d", tc.codes, got, tc.want)
+			}
+		})
+	}
+}
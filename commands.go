@@ -0,0 +1,875 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// command is a subcommand of the CLI. setup registers the command's flags and
+// returns the function that runs it once the flags have been parsed.
+type command struct {
+	name    string
+	summary string
+	args    string // Positional arguments shown in the usage line, empty if none
+	example string // Arguments of a typical invocation, shown at the end of the usage text
+	setup   func(fs *flag.FlagSet, global *globalOptions) func(ctx context.Context, args []string)
+}
+
+// commands returns the subcommands in the order they are listed in the usage text
+func commands() []command {
+	return []command{
+		{name: "sync", summary: "Create missing topics and increase partitions of existing ones",
+			example: "-config topics.yaml -env prod", setup: setupSync},
+		{name: "create", summary: "Create missing topics, leaving existing topics untouched",
+			example: "-config topics.yaml -created-file created.json", setup: setupCreate},
+		{name: "plan", summary: "Show the changes sync would make without applying them",
+			example: "-config topics.yaml -diff-exit-code", setup: setupPlan},
+		{name: "delete", summary: "Delete the configured topics from the cluster",
+			example: "-config obsolete-topics.yaml -yes", setup: setupDelete},
+		{name: "list", summary: "List the configured topics, or the cluster's topics with -existing",
+			example: "-existing -sort partitions", setup: setupList},
+		{name: "export", summary: "Print the cluster's topics as a topics configuration file",
+			example: "-file topics.yaml -filter '^orders\\.'", setup: setupExport},
+		{name: "export-state", summary: "Save the cluster's topics, partitions and configs for plan -state-file",
+			example: "-file state.json", setup: setupExportState},
+		{name: "describe", summary: "Print partitions, replicas, ISR and non-default configs of a topic", args: "<topic>",
+			example: "orders.events", setup: setupDescribe},
+		{name: "health", summary: "Report offline and under-replicated partitions of the configured topics",
+			example: "-config topics.yaml", setup: setupHealth},
+		{name: "show-config", summary: "Print the effective connection configuration (secrets redacted)",
+			setup: setupShowConfig},
+		{name: "completion", summary: "Print a shell completion script for bash, zsh or fish", args: "<shell>",
+			example: "bash", setup: setupCompletion},
+	}
+}
+
+// findCommand looks up a subcommand by name
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands() {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// configFlags are the flags of commands that read a topics configuration file
+type configFlags struct {
+	command            string
+	file               string
+	environment        string
+	template           bool
+	values             string
+	allowUnknownConfig bool
+	maxPartitions      int
+	force              bool
+	failOnEmpty        bool
+	lenient            bool
+	partitions         int
+	replicationFactor  int
+	only               string
+	protected          string
+	dumpSpecs          bool
+	policy             string
+	withACLs           bool
+
+	// parsed is the configuration file once topicsConfig has read it
+	parsed *TopicsConfig
+}
+
+// addConfigFlags registers the flags controlling how the topics configuration is loaded
+func addConfigFlags(fs *flag.FlagSet) *configFlags {
+	f := &configFlags{command: fs.Name()}
+	fs.StringVar(&f.file, "config", "", "Path or HTTP(S) URL of the topics configuration file (required, defaults to KAFKA_CONFIG_FILE)")
+	fs.StringVar(&f.environment, "env", "", "Environment section of the config file to apply, e.g. dev or prod")
+	fs.BoolVar(&f.template, "template", false, "Render the config file with Go text/template before parsing it")
+	fs.StringVar(&f.values, "values", "", "YAML file with values for -template rendering")
+	fs.BoolVar(&f.allowUnknownConfig, "allow-unknown-config", false, "Accept per-topic config keys not known to this tool")
+	fs.IntVar(&f.maxPartitions, "max-partitions", 1000, "Maximum partitions allowed per topic (0 disables the limit)")
+	fs.BoolVar(&f.force, "force", false, "Override safety limits such as -max-partitions")
+	fs.BoolVar(&f.failOnEmpty, "fail-on-empty", false, "Exit non-zero when the config file defines no topics")
+	fs.BoolVar(&f.lenient, "lenient", false, "Ignore unknown fields in the config file instead of rejecting them")
+	fs.StringVar(&f.policy, "policy", "", "Policy file listing the config keys topics may set (defaults to KAFKA_POLICY_FILE)")
+	return f
+}
+
+// options returns the LoadOptions selected by the flags
+func (f *configFlags) options() LoadOptions {
+	if f.policy == "" {
+		f.policy = defaultPolicyFile()
+	}
+	return LoadOptions{
+		AllowUnknownConfig:    f.allowUnknownConfig,
+		MaxPartitions:         f.maxPartitions,
+		AllowExcessPartitions: f.force,
+		Environment:           f.environment,
+		Template:              f.template,
+		ValuesFile:            f.values,
+		Partitions:            f.partitions,
+		ReplicationFactor:     f.replicationFactor,
+		Lenient:               f.lenient,
+		PolicyFile:            f.policy,
+	}
+}
+
+// addOverrideFlags registers the flags replacing values of every configured topic,
+// for the commands that apply the configuration
+func addOverrideFlags(fs *flag.FlagSet, f *configFlags) {
+	fs.IntVar(&f.partitions, "partitions", 0, "Use this partition count for every topic instead of the config's (0 keeps the config)")
+	fs.IntVar(&f.replicationFactor, "replication-factor", 0, "Use this replication factor for every topic instead of the config's (0 keeps the config)")
+	fs.StringVar(&f.only, "only", "", "Comma-separated topic names to apply, ignoring the rest of the config")
+	fs.BoolVar(&f.dumpSpecs, "dump-specs", false, "Print the resolved topic specs as JSON and exit without connecting")
+}
+
+// configFile returns the -config path, falling back to KAFKA_CONFIG_FILE, and exits
+// with usage instructions when neither is set
+func (f *configFlags) configFile() string {
+	if f.file == "" {
+		f.file = defaultConfigFile()
+	}
+	if f.file == "" {
+		fmt.Fprintln(messageOut, "❌ Error: -config flag (or KAFKA_CONFIG_FILE) is required")
+		fmt.Fprintf(messageOut, "Usage: %s %s -config <config-file.yaml> [options]\n", os.Args[0], f.command)
+		fmt.Fprintf(messageOut, "Example: %s %s -config topics.yaml\n", os.Args[0], f.command)
+		exit(failureExitCode(exitConfigError))
+	}
+	return f.file
+}
+
+// topicsConfig reads and parses the configuration file on first use, so the topics,
+// protected block, clusters and ACLs of a run all come from a single read; it exits
+// when the file cannot be read or parsed
+func (f *configFlags) topicsConfig() TopicsConfig {
+	if f.parsed == nil {
+		config, err := readTopicsFile(f.configFile(), f.options())
+		if err != nil {
+			exitWithError(exitConfigError, "❌ Failed to load topic configurations: %v", err)
+		}
+		f.parsed = &config
+	}
+	return *f.parsed
+}
+
+// loadTopics loads the topic specifications from the configuration file, exiting on
+// error; with -dump-specs it prints them and exits instead
+func (f *configFlags) loadTopics() []kafka.TopicSpecification {
+	// Keep stdout for the JSON, moving messages and warnings printed while loading to stderr
+	if f.dumpSpecs {
+		messageOut = os.Stderr
+		statusOut = os.Stderr
+	}
+
+	topicConfigs, err := GetAllTopicConfigs(f.topicsConfig(), f.options())
+	if err != nil {
+		exitWithError(exitConfigError, "❌ Failed to load topic configurations: %v", err)
+	}
+	if len(topicConfigs) == 0 && f.failOnEmpty {
+		exitWithError(exitConfigError, "❌ No topics defined in %s (-fail-on-empty)", f.file)
+	}
+
+	if f.only != "" {
+		selected, err := selectTopicSpecs(topicConfigs, splitNames(f.only))
+		if err != nil {
+			exitWithError(exitConfigError, "❌ Invalid -only: %v", err)
+		}
+		fmt.Fprintf(messageOut, "🎯 Applying %d of %d configured topics (-only)\n", len(selected), len(topicConfigs))
+		topicConfigs = selected
+	}
+
+	if f.dumpSpecs {
+		if err := printTopicSpecs(os.Stdout, topicConfigs); err != nil {
+			exitWithError(exitFailure, "❌ %v", err)
+		}
+		exit(exitOK)
+	}
+	return topicConfigs
+}
+
+// splitNames splits a comma-separated flag value into names, dropping empty entries
+func splitNames(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// addProtectedFlag registers the topics that must never be altered or deleted, in
+// addition to the protected block of the config file
+func addProtectedFlag(fs *flag.FlagSet, f *configFlags) {
+	fs.StringVar(&f.protected, "protected", "", "Comma-separated topic names never altered or deleted, added to the config's protected block")
+}
+
+// addACLFlag registers -with-acls, which applies the acls blocks of the config
+func addACLFlag(fs *flag.FlagSet, f *configFlags) {
+	fs.BoolVar(&f.withACLs, "with-acls", false, "Apply the acls block of each topic after creating the topics")
+}
+
+// topicACLs returns the ACL bindings of the config with -with-acls, or none without
+// it, exiting when they are invalid
+func (f *configFlags) topicACLs() []kafka.ACLBinding {
+	if !f.withACLs {
+		return nil
+	}
+	acls, err := GetTopicACLs(f.topicsConfig(), f.environment)
+	if err != nil {
+		exitWithError(exitConfigError, "❌ Failed to load topic ACLs: %v", err)
+	}
+	return acls
+}
+
+// protectedTopics returns the protected block of the config file with the -protected
+// names
+func (f *configFlags) protectedTopics() []string {
+	return f.addProtectedNames(slices.Clone(f.topicsConfig().Protected))
+}
+
+// addProtectedNames appends the -protected names to the protected topics and
+// announces how many there are
+func (f *configFlags) addProtectedNames(protected []string) []string {
+	protected = append(protected, splitNames(f.protected)...)
+	if len(protected) > 0 {
+		fmt.Fprintf(messageOut, "🛡️  %d topics are protected and will not be altered or deleted\n", len(protected))
+	}
+	return protected
+}
+
+// hasConfigFile reports whether -config or KAFKA_CONFIG_FILE names a config file, for
+// commands where one is optional
+func (f *configFlags) hasConfigFile() bool {
+	if f.file == "" {
+		f.file = defaultConfigFile()
+	}
+	return f.file != ""
+}
+
+// requireSingleCluster exits when the configuration declares a clusters block, which
+// only the sync command applies
+func (f *configFlags) requireSingleCluster() {
+	clusters, err := GetClusterConfigs(f.topicsConfig())
+	if err != nil {
+		exitWithError(exitConfigError, "❌ Failed to load cluster configurations: %v", err)
+	}
+	if len(clusters) > 0 {
+		exitWithError(exitConfigError, "❌ The clusters block is only supported by the sync command")
+	}
+}
+
+// addApplyFlags registers the flags tuning commands that change topics on the cluster
+func addApplyFlags(fs *flag.FlagSet) *ManagerOptions {
+	opts := &ManagerOptions{}
+	fs.BoolVar(&opts.IncludeInternal, "include-internal", false, "Manage internal topics (__*, _confluent*) instead of skipping them")
+	fs.IntVar(&opts.BatchSize, "batch-size", 100, "Maximum topics per CreateTopics request (0 for a single request)")
+	fs.DurationVar(&opts.OpDelay, "op-delay", 0, "Delay between consecutive admin operations, e.g. 500ms")
+	addReplicationMaxFlag(fs, opts)
+	addMetadataFlags(fs, opts)
+	fs.DurationVar(&opts.CreateTimeout, "create-timeout", 0,
+		"Timeout of each CreateTopics request, e.g. 2m (0 for no limit besides -timeout)")
+	fs.BoolVar(&opts.WaitForDeletion, "wait", false, "Wait for topics still being deleted to disappear, then create them")
+	fs.IntVar(&opts.MinBrokers, "min-brokers", 0, "Abort before changing anything when fewer brokers are available (0 disables the check)")
+	addProgressFlags(fs, opts)
+	return opts
+}
+
+// addDryRunFlag registers -dry-run, which applies changes to an in-memory copy of the cluster
+func addDryRunFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("dry-run", false, "Apply changes to an in-memory copy of the cluster instead of Kafka")
+}
+
+// addResultLineFlag registers -result-line, which ends the output with the counts of the run
+func addResultLineFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("result-line", false, "End with a line like 'RESULT created=3 updated=1 unchanged=10 failed=0' on stdout in every output mode")
+}
+
+// connectTopicAdmin connects to the cluster like connectAdmin; for a dry run it copies
+// the cluster into a FakeAdmin and closes the real client
+func connectTopicAdmin(ctx context.Context, server string, dryRun bool) KafkaAdmin {
+	adminClient := connectAdmin(server)
+	if !dryRun {
+		return adminClient
+	}
+	defer adminClient.Close()
+
+	fake, err := NewFakeAdminFromCluster(ctx, adminClient)
+	if err != nil {
+		exitWithError(authExitCode(err, exitConnectionError), "❌ Failed to copy the cluster for the dry run: %v", err)
+	}
+	fmt.Fprintln(statusOut, "🧪 Dry run: changes go to an in-memory copy of the cluster, Kafka is not modified")
+	return fake
+}
+
+// addMetadataFlags registers the switch from cluster-wide to per-topic metadata
+// requests and the timeout of each request
+func addMetadataFlags(fs *flag.FlagSet, opts *ManagerOptions) {
+	fs.BoolVar(&opts.TargetedMetadata, "targeted-metadata", false,
+		"Fetch metadata only for the configured topics instead of all topics in the cluster")
+	fs.DurationVar(&opts.MetadataTimeout, "metadata-timeout", 0,
+		"Timeout of each metadata request, e.g. 10s (0 for 5s, or the rest of -timeout when set)")
+	fs.IntVar(&opts.MetadataAttempts, "metadata-attempts", 3,
+		"Attempts of a metadata request failing with a transient error, with a growing pause in between")
+}
+
+// addReplicationMaxFlag registers the cap applied to replication_factor: auto
+func addReplicationMaxFlag(fs *flag.FlagSet, opts *ManagerOptions) {
+	fs.IntVar(&opts.ReplicationMax, "replication-max", 3, "Maximum replication factor chosen for replication_factor: auto")
+}
+
+// addConfigModeFlag registers how config drift on existing topics is applied
+func addConfigModeFlag(fs *flag.FlagSet, opts *ManagerOptions) {
+	fs.StringVar(&opts.ConfigMode, "config-mode", configModeIncremental,
+		"How config changes are applied to existing topics: incremental (set only the listed keys) or full (reset all others)")
+}
+
+// addStagingFlags registers the switches limiting what sync changes: only creating
+// missing topics, only updating existing ones, or also increasing compacted topics
+func addStagingFlags(fs *flag.FlagSet, opts *ManagerOptions) {
+	fs.BoolVar(&opts.CreateOnly, "create-only", false,
+		"Only create missing topics; existing topics are skipped, never updated")
+	fs.BoolVar(&opts.UpdateOnly, "update-only", false,
+		"Only update existing topics; missing topics are skipped, never created")
+	fs.BoolVar(&opts.AllowPartitionIncrease, "allow-partition-increase", false,
+		"Increase partitions of compacted topics too, although keys move to other partitions")
+}
+
+// validateStagingFlags exits when -create-only and -update-only are combined
+func validateStagingFlags(opts ManagerOptions) {
+	if opts.CreateOnly && opts.UpdateOnly {
+		exitWithError(exitConfigError, "❌ -create-only and -update-only cannot be combined")
+	}
+}
+
+// validateConfigMode exits when -config-mode is not a supported mode
+func validateConfigMode(opts ManagerOptions) {
+	if opts.ConfigMode != configModeIncremental && opts.ConfigMode != configModeFull {
+		exitWithError(exitConfigError, "❌ Unknown -config-mode '%s' (expected incremental or full)", opts.ConfigMode)
+	}
+}
+
+// addProgressFlags registers the flags controlling per-topic progress output
+func addProgressFlags(fs *flag.FlagSet, opts *ManagerOptions) {
+	fs.BoolVar(&opts.Quiet, "quiet", false, "Suppress per-topic informational lines and progress (warnings and errors are still shown)")
+	fs.StringVar(&opts.LogFormat, "log-format", "text", "Per-topic progress format: text or json")
+	fs.StringVar(&opts.Output, "output", "text", "Output format: text, or jsonl to stream one JSON line per operation on stdout")
+	fs.BoolVar(&opts.SummaryOnly, "summary-only", false,
+		"Print only the final summary line (only the summary event with -log-format json); unlike -quiet, warnings are hidden too")
+}
+
+// validateProgressFlags exits when -log-format or -output is not a supported format,
+// and starts the jsonl stream when requested
+func validateProgressFlags(opts ManagerOptions) {
+	if opts.LogFormat != "text" && opts.LogFormat != "json" {
+		exitWithError(exitConfigError, "❌ Unknown -log-format '%s' (expected text or json)", opts.LogFormat)
+	}
+	switch opts.Output {
+	case "text":
+	case "jsonl":
+		streamOperations()
+	default:
+		exitWithError(exitConfigError, "❌ Unknown -output '%s' (expected text or jsonl)", opts.Output)
+	}
+	if opts.SummaryOnly {
+		showSummaryOnly()
+	}
+}
+
+// setupSync registers the flags of sync, the default command, which applies the config
+func setupSync(fs *flag.FlagSet, global *globalOptions) func(ctx context.Context, args []string) {
+	config := addConfigFlags(fs)
+	addOverrideFlags(fs, config)
+	managerOptions := addApplyFlags(fs)
+	fs.BoolVar(&managerOptions.CheckBrokerLimits, "check-broker-limits", false,
+		"Warn when a topic's max.message.bytes exceeds the broker's message.max.bytes")
+	addConfigModeFlag(fs, managerOptions)
+	addStagingFlags(fs, managerOptions)
+	addProtectedFlag(fs, config)
+	addACLFlag(fs, config)
+	fs.BoolVar(&managerOptions.Verify, "verify", false,
+		"After applying, re-fetch the cluster state and fail if a topic's partitions or configs do not match")
+	fs.BoolVar(&managerOptions.Rebalance, "rebalance", false,
+		"After applying, move the partition leaders of configured, unprotected topics back to their preferred replicas")
+	strict := fs.Bool("strict", false, "Treat warnings (e.g. partitions that cannot be scaled down) as errors")
+	createdFile := fs.String("created-file", "", "Write the names of newly created topics to this file (JSON if it ends in .json)")
+	resultLine := addResultLineFlag(fs)
+	dryRun := addDryRunFlag(fs)
+
+	return func(ctx context.Context, args []string) {
+		resultLinePending = *resultLine
+		validateProgressFlags(*managerOptions)
+		validateConfigMode(*managerOptions)
+		validateStagingFlags(*managerOptions)
+		topicConfigs := config.loadTopics()
+		managerOptions.Protected = config.protectedTopics()
+		managerOptions.ACLs = config.topicACLs()
+
+		fmt.Fprintln(messageOut, "🚀 Starting Kafka Topic Creation Tool")
+		fmt.Fprintln(messageOut, "Press Ctrl+C to cancel...")
+
+		// Sync every declared cluster, or the single cluster from the environment
+		clusters, err := GetClusterConfigs(config.topicsConfig())
+		if err != nil {
+			exitWithError(exitConfigError, "❌ Failed to load cluster configurations: %v", err)
+		}
+		if len(clusters) > 0 {
+			if global.server != "" {
+				exitWithError(exitConfigError, "❌ -server cannot be combined with a clusters block in the config file")
+			}
+			fmt.Fprintf(messageOut, "📋 Syncing %d topics across %d clusters\n", len(topicConfigs), len(clusters))
+			result, code := syncClusters(ctx, clusters, topicConfigs, *managerOptions, *strict, *dryRun)
+			code = recordCreatedTopics(*createdFile, result.CreatedTopics, code)
+			if *resultLine {
+				printResultLine(result)
+			}
+			if code != exitOK {
+				exit(code)
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			fmt.Fprintln(messageOut, "✅ Topic sync process completed successfully!")
+			return
+		}
+
+		adminClient := connectTopicAdmin(ctx, global.server, *dryRun)
+		defer adminClient.Close()
+
+		topicManager := NewTopicManager(adminClient, *managerOptions)
+
+		topicCount := len(topicConfigs)
+		fmt.Fprintf(messageOut, "📋 Syncing %d topics with predefined configurations\n", topicCount)
+
+		result, code := runSync(ctx, topicManager, topicConfigs, *strict)
+		code = recordCreatedTopics(*createdFile, result.CreatedTopics, code)
+		if *resultLine {
+			printResultLine(result)
+		}
+		if code != exitOK {
+			exit(code)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		fmt.Fprintln(messageOut, "✅ Topic sync process completed successfully!")
+	}
+}
+
+// setupCreate registers the flags of create, which only adds missing topics
+func setupCreate(fs *flag.FlagSet, global *globalOptions) func(ctx context.Context, args []string) {
+	config := addConfigFlags(fs)
+	addOverrideFlags(fs, config)
+	managerOptions := addApplyFlags(fs)
+	fs.BoolVar(&managerOptions.StrictCreate, "strict-create", false,
+		"Fail topics that already exist with partitions or configs other than requested")
+	addACLFlag(fs, config)
+	createdFile := fs.String("created-file", "", "Write the names of newly created topics to this file (JSON if it ends in .json)")
+	resultLine := addResultLineFlag(fs)
+	dryRun := addDryRunFlag(fs)
+
+	return func(ctx context.Context, args []string) {
+		resultLinePending = *resultLine
+		validateProgressFlags(*managerOptions)
+		topicConfigs := config.loadTopics()
+		config.requireSingleCluster()
+		managerOptions.ACLs = config.topicACLs()
+
+		fmt.Fprintln(messageOut, "🚀 Starting Kafka Topic Creation Tool")
+		fmt.Fprintln(messageOut, "Press Ctrl+C to cancel...")
+
+		adminClient := connectTopicAdmin(ctx, global.server, *dryRun)
+		defer adminClient.Close()
+
+		fmt.Fprintf(messageOut, "📋 Creating %d topics with predefined configurations\n", len(topicConfigs))
+		result, err := NewTopicManager(adminClient, *managerOptions).CreateTopics(ctx, topicConfigs)
+
+		code := exitOK
+		if err != nil {
+			if ctx.Err() == context.Canceled {
+				fmt.Fprintln(messageOut, "✅ Topic creation cancelled by user")
+				return
+			}
+			log.Printf("❌ Failed to create topics: %v", err)
+			if ctx.Err() == context.DeadlineExceeded {
+				reportTimeout(result)
+			}
+			code = syncExitCode(result, err)
+		}
+		code = recordCreatedTopics(*createdFile, result.CreatedTopics, code)
+		if *resultLine {
+			printResultLine(result)
+		}
+		if code != exitOK {
+			exit(code)
+		}
+
+		fmt.Fprintln(messageOut, "✅ Topic creation completed successfully!")
+	}
+}
+
+// setupPlan registers the flags of plan, a read-only preview of sync
+func setupPlan(fs *flag.FlagSet, global *globalOptions) func(ctx context.Context, args []string) {
+	config := addConfigFlags(fs)
+	addOverrideFlags(fs, config)
+	managerOptions := &ManagerOptions{}
+	fs.BoolVar(&managerOptions.IncludeInternal, "include-internal", false, "Plan internal topics (__*, _confluent*) instead of skipping them")
+	addReplicationMaxFlag(fs, managerOptions)
+	addMetadataFlags(fs, managerOptions)
+	fs.BoolVar(&managerOptions.CheckBrokerLimits, "check-broker-limits", false,
+		"Warn when a topic's max.message.bytes exceeds the broker's message.max.bytes")
+	addConfigModeFlag(fs, managerOptions)
+	addStagingFlags(fs, managerOptions)
+	addProtectedFlag(fs, config)
+	diffExitCode := fs.Bool("diff-exit-code", false,
+		"Exit 0 when the cluster matches the config, 2 when changes are needed and 1 on error")
+	stateFile := fs.String("state-file", "", "Plan against a cluster state file written by export-state instead of the live cluster")
+	output := fs.String("output", "text", "Output format: text, or yaml to print the topics to create as a topics configuration")
+
+	return func(ctx context.Context, args []string) {
+		if *diffExitCode {
+			errorExitCode = exitFailure
+		}
+		validateConfigMode(*managerOptions)
+		validateStagingFlags(*managerOptions)
+
+		// Keep stdout for the YAML, moving the plan and diagnostics to stderr
+		switch *output {
+		case "text":
+		case "yaml":
+			messageOut = os.Stderr
+			statusOut = os.Stderr
+		default:
+			exitWithError(exitConfigError, "❌ Unknown -output '%s' (expected text or yaml)", *output)
+		}
+
+		topicConfigs := config.loadTopics()
+		config.requireSingleCluster()
+		managerOptions.Protected = config.protectedTopics()
+
+		var adminClient KafkaAdmin
+		if *stateFile != "" {
+			fake, err := NewFakeAdminFromState(*stateFile)
+			if err != nil {
+				exitWithError(exitConfigError, "❌ %v", err)
+			}
+			fmt.Fprintf(messageOut, "📂 Planning against the cluster state in %s\n", *stateFile)
+			adminClient = fake
+		} else {
+			adminClient = connectAdmin(global.server)
+		}
+		defer adminClient.Close()
+
+		plan, err := NewTopicManager(adminClient, *managerOptions).PlanSync(ctx, topicConfigs)
+		if err != nil {
+			exitWithError(syncExitCode(SyncResult{}, err), "❌ Failed to plan sync: %v", err)
+		}
+		printSyncPlan(plan)
+		if *output == "yaml" {
+			if err := writeTopicsConfig(os.Stdout, topicsConfigFromSpecs(plan.ToCreate)); err != nil {
+				exitWithError(exitFailure, "❌ %v", err)
+			}
+		}
+		if *diffExitCode && plan.hasChanges() {
+			exit(exitPlanChanges)
+		}
+	}
+}
+
+// setupDelete registers the flags of delete, which removes the configured topics, or
+// those of a -delete-list file, after confirmation
+func setupDelete(fs *flag.FlagSet, global *globalOptions) func(ctx context.Context, args []string) {
+	config := addConfigFlags(fs)
+	managerOptions := &ManagerOptions{}
+	fs.BoolVar(&managerOptions.IncludeInternal, "include-internal", false, "Delete internal topics (__*, _confluent*) instead of skipping them")
+	addProgressFlags(fs, managerOptions)
+	addProtectedFlag(fs, config)
+	yes := fs.Bool("yes", false, "Delete without asking for confirmation (same as -force)")
+	deleteList := fs.String("delete-list", "", "File listing the topics to delete, one per line, instead of the config's topics")
+	dryRun := addDryRunFlag(fs)
+
+	return func(ctx context.Context, args []string) {
+		validateProgressFlags(*managerOptions)
+
+		// The config file is optional with -delete-list, providing only its protected block
+		var names []string
+		if *deleteList != "" {
+			listed, err := readTopicListFile(*deleteList)
+			if err != nil {
+				exitWithError(exitConfigError, "❌ Invalid -delete-list: %v", err)
+			}
+			names = listed
+			if config.hasConfigFile() {
+				managerOptions.Protected = config.protectedTopics()
+			} else {
+				managerOptions.Protected = config.addProtectedNames(nil)
+			}
+		} else {
+			for _, spec := range config.loadTopics() {
+				names = append(names, spec.Topic)
+			}
+			config.requireSingleCluster()
+			managerOptions.Protected = config.protectedTopics()
+		}
+
+		adminClient := connectTopicAdmin(ctx, global.server, *dryRun)
+		defer adminClient.Close()
+
+		topicManager := NewTopicManager(adminClient, *managerOptions)
+		existingTopics, err := topicManager.GetExistingTopics(ctx)
+		if err != nil {
+			exitWithError(authExitCode(err, exitConnectionError), "❌ Failed to get existing topics: %v", err)
+		}
+
+		// Only topics that exist are deleted; internal ones are hidden from existingTopics
+		var topics []string
+		for _, name := range names {
+			if isInternalTopic(name) && !managerOptions.IncludeInternal {
+				fmt.Fprintf(messageOut, "⚠️  Skipping internal topic '%s' (use -include-internal to manage it)\n", name)
+				continue
+			}
+			if topicManager.isProtected(name) {
+				fmt.Fprintf(messageOut, "🛡️  Refusing to delete protected topic '%s'\n", name)
+				continue
+			}
+			if _, exists := existingTopics[name]; exists {
+				topics = append(topics, name)
+			} else if *deleteList != "" && !managerOptions.Quiet {
+				fmt.Fprintf(messageOut, "ℹ️  Topic '%s' does not exist, skipping\n", name)
+			}
+		}
+		if len(topics) == 0 {
+			if *deleteList != "" {
+				fmt.Fprintln(messageOut, "ℹ️  None of the listed topics exist, nothing to delete")
+			} else {
+				fmt.Fprintln(messageOut, "ℹ️  None of the configured topics exist, nothing to delete")
+			}
+			return
+		}
+
+		if err := confirmDestructive("delete", topics, config.force || *yes || *dryRun); err != nil {
+			exitWithError(exitFailure, "❌ %v", err)
+		}
+
+		deleted, err := topicManager.DeleteTopics(ctx, topics)
+		if err != nil {
+			failed := len(topics) - len(deleted)
+			exitWithError(outcomeExitCode(len(deleted), failed, err), "❌ Failed to delete topics: %v", err)
+		}
+
+		fmt.Fprintln(messageOut, "✅ Topic deletion completed successfully!")
+	}
+}
+
+// setupList registers the flags of list
+func setupList(fs *flag.FlagSet, global *globalOptions) func(ctx context.Context, args []string) {
+	config := addConfigFlags(fs)
+	listOptions := &ListOptions{}
+	fs.StringVar(&listOptions.SortBy, "sort", "name", "Sort output by name, partitions or replication")
+	fs.IntVar(&listOptions.Limit, "limit", 0, "Maximum number of topics printed (0 for all)")
+	fs.StringVar(&listOptions.Output, "output", "table", "Output format: table or json")
+	fs.StringVar(&listOptions.Filter, "filter", "", "Regular expression selecting topic names shown")
+	existing := fs.Bool("existing", false, "List topics that exist on the cluster instead of the config")
+	includeInternal := fs.Bool("include-internal", false, "Include internal topics (__*, _confluent*) in -existing listings")
+
+	return func(ctx context.Context, args []string) {
+		if listOptions.Output == "json" {
+			statusOut = os.Stderr
+		}
+
+		// Handle listing topics that exist on the cluster
+		if *existing {
+
+			adminClient := connectAdmin(global.server)
+			defer adminClient.Close()
+
+			topicManager := NewTopicManager(adminClient, ManagerOptions{IncludeInternal: *includeInternal})
+			existingTopics, err := topicManager.ListTopics(ctx)
+			if err != nil {
+				exitWithError(authExitCode(err, exitConnectionError), "❌ Failed to get existing topics: %v", err)
+			}
+			if err := printTopicList(specsFromTopicInfo(existingTopics), *listOptions); err != nil {
+				exitWithError(exitConfigError, "❌ Failed to list topics: %v", err)
+			}
+			return
+		}
+
+		if err := printTopicList(config.loadTopics(), *listOptions); err != nil {
+			exitWithError(exitConfigError, "❌ Failed to list topics: %v", err)
+		}
+	}
+}
+
+// setupExport registers the flags of export, which captures the cluster's topics as YAML
+func setupExport(fs *flag.FlagSet, global *globalOptions) func(ctx context.Context, args []string) {
+	file := fs.String("file", "", "Write the configuration to this file instead of stdout")
+	filter := fs.String("filter", "", "Regular expression selecting the topic names exported")
+	includeInternal := fs.Bool("include-internal", false, "Export internal topics (__*, _confluent*) too")
+
+	return func(ctx context.Context, args []string) {
+		var re *regexp.Regexp
+		if *filter != "" {
+			var err error
+			if re, err = regexp.Compile(*filter); err != nil {
+				exitWithError(exitConfigError, "❌ Invalid filter pattern '%s': %v", *filter, err)
+			}
+		}
+
+		// The configuration goes to stdout unless -file is given, so keep diagnostics off it
+		if *file == "" {
+			statusOut = os.Stderr
+		}
+
+		adminClient := connectAdmin(global.server)
+		defer adminClient.Close()
+
+		exported, err := NewTopicManager(adminClient, ManagerOptions{IncludeInternal: *includeInternal}).ExportTopics(ctx)
+		if err != nil {
+			exitWithError(syncExitCode(SyncResult{}, err), "❌ Failed to export topics: %v", err)
+		}
+		if re != nil {
+			var topics []TopicConfig
+			for _, topic := range exported.Topics {
+				if re.MatchString(topic.Name) {
+					topics = append(topics, topic)
+				}
+			}
+			exported.Topics = topics
+		}
+
+		out := os.Stdout
+		if *file != "" {
+			if out, err = os.Create(*file); err != nil {
+				exitWithError(exitFailure, "❌ Failed to create export file: %v", err)
+			}
+			defer out.Close()
+		}
+		if err := writeTopicsConfig(out, exported); err != nil {
+			exitWithError(exitFailure, "❌ %v", err)
+		}
+		if *file != "" {
+			fmt.Fprintf(messageOut, "📝 Exported %d topics to %s\n", len(exported.Topics), *file)
+		}
+	}
+}
+
+// setupExportState registers the flags of export-state, which snapshots the cluster
+// for offline planning with plan -state-file
+func setupExportState(fs *flag.FlagSet, global *globalOptions) func(ctx context.Context, args []string) {
+	file := fs.String("file", "", "Write the cluster state to this file instead of stdout")
+
+	return func(ctx context.Context, args []string) {
+		// The state goes to stdout unless -file is given, so keep diagnostics off it
+		if *file == "" {
+			statusOut = os.Stderr
+		}
+
+		adminClient := connectAdmin(global.server)
+		defer adminClient.Close()
+
+		snapshot, err := NewFakeAdminFromCluster(ctx, adminClient)
+		if err != nil {
+			exitWithError(syncExitCode(SyncResult{}, err), "❌ Failed to read the cluster state: %v", err)
+		}
+
+		out := os.Stdout
+		if *file != "" {
+			if out, err = os.Create(*file); err != nil {
+				exitWithError(exitFailure, "❌ Failed to create state file: %v", err)
+			}
+			defer out.Close()
+		}
+		if err := snapshot.writeState(out); err != nil {
+			exitWithError(exitFailure, "❌ %v", err)
+		}
+		if *file != "" {
+			fmt.Fprintf(messageOut, "📝 Exported the state of %d topics to %s\n", len(snapshot.topics), *file)
+		}
+	}
+}
+
+// setupDescribe registers the flags of describe
+func setupDescribe(fs *flag.FlagSet, global *globalOptions) func(ctx context.Context, args []string) {
+	output := fs.String("output", "table", "Output format: table or json")
+
+	return func(ctx context.Context, args []string) {
+		if len(args) != 1 {
+			exitWithError(exitConfigError, "❌ describe expects exactly one topic name")
+		}
+		if *output == "json" {
+			statusOut = os.Stderr
+		}
+
+		adminClient := connectAdmin(global.server)
+		defer adminClient.Close()
+
+		description, err := NewTopicManager(adminClient, ManagerOptions{}).DescribeTopic(ctx, args[0])
+		if err != nil {
+			exitWithError(syncExitCode(SyncResult{}, err), "❌ Failed to describe topic: %v", err)
+		}
+		if err := printTopicDescription(description, *output); err != nil {
+			exitWithError(exitConfigError, "❌ Failed to print topic description: %v", err)
+		}
+	}
+}
+
+// setupHealth registers the flags of health
+func setupHealth(fs *flag.FlagSet, global *globalOptions) func(ctx context.Context, args []string) {
+	config := addConfigFlags(fs)
+	managerOptions := &ManagerOptions{}
+	addMetadataFlags(fs, managerOptions)
+
+	return func(ctx context.Context, args []string) {
+		topicConfigs := config.loadTopics()
+		config.requireSingleCluster()
+
+		adminClient := connectAdmin(global.server)
+		defer adminClient.Close()
+
+		issues, err := NewTopicManager(adminClient, *managerOptions).HealthReport(ctx, topicConfigs)
+		if err != nil {
+			exitWithError(syncExitCode(SyncResult{}, err), "❌ Failed to build health report: %v", err)
+		}
+		printHealthReport(issues, len(topicConfigs))
+	}
+}
+
+// setupShowConfig registers show-config, which only uses the global flags
+func setupShowConfig(fs *flag.FlagSet, global *globalOptions) func(ctx context.Context, args []string) {
+	return func(ctx context.Context, args []string) {
+		config, err := loadConfig()
+		if err != nil {
+			exitWithError(exitConfigError, "❌ Failed to load configuration: %v", err)
+		}
+		if global.server != "" {
+			if config.Server, err = normalizeServers(global.server); err != nil {
+				exitWithError(exitConfigError, "❌ Invalid -server: %v", err)
+			}
+		}
+		if err := printEffectiveConfig(config); err != nil {
+			exitWithError(exitConfigError, "❌ Failed to print configuration: %v", err)
+		}
+	}
+}
+
+// setupCompletion registers completion, which takes the shell as its argument
+func setupCompletion(fs *flag.FlagSet, global *globalOptions) func(ctx context.Context, args []string) {
+	return func(ctx context.Context, args []string) {
+		if len(args) != 1 {
+			exitWithError(exitConfigError, "❌ completion expects a shell name: bash, zsh or fish")
+		}
+
+		script, err := generateCompletion(args[0], completionCommands())
+		if err != nil {
+			exitWithError(exitConfigError, "❌ Failed to generate completion: %v", err)
+		}
+		fmt.Fprint(messageOut, script)
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// completionCommand is the binary name completion scripts are registered for
+const completionCommand = "kafka-topic-creator"
+
+// completionFlag describes a command-line flag for completion scripts
+type completionFlag struct {
+	name   string
+	usage  string
+	isBool bool
+	isPath bool
+}
+
+// completionSubcommand describes a subcommand and its flags for completion scripts
+type completionSubcommand struct {
+	name    string
+	summary string
+	flags   []completionFlag
+}
+
+// completionFlags collects the registered flags, marking which take file paths
+func completionFlags(flags *flag.FlagSet) []completionFlag {
+	var result []completionFlag
+	flags.VisitAll(func(f *flag.Flag) {
+		boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool })
+		result = append(result, completionFlag{
+			name:   f.Name,
+			usage:  f.Usage,
+			isBool: ok && boolFlag.IsBoolFlag(),
+			isPath: f.Name == "config" || f.Name == "values" || f.Name == "file" || strings.HasSuffix(f.Name, "-file"),
+		})
+	})
+	return result
+}
+
+// completionCommands collects the flags of every subcommand by running its setup on
+// a throwaway flag set
+func completionCommands() []completionSubcommand {
+	var result []completionSubcommand
+	for _, cmd := range commands() {
+		global := &globalOptions{}
+		fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+		addGlobalFlags(fs, global)
+		cmd.setup(fs, global)
+		result = append(result, completionSubcommand{
+			name:    cmd.name,
+			summary: cmd.summary,
+			flags:   completionFlags(fs),
+		})
+	}
+	return result
+}
+
+// globalCompletionFlags returns the flags accepted before the command name
+func globalCompletionFlags() []completionFlag {
+	fs := flag.NewFlagSet(completionCommand, flag.ContinueOnError)
+	addGlobalFlags(fs, &globalOptions{})
+	return completionFlags(fs)
+}
+
+// generateCompletion returns a completion script for the given shell, completing the
+// command name first and then the flags of the chosen command
+func generateCompletion(shell string, subcommands []completionSubcommand) (string, error) {
+	globals := globalCompletionFlags()
+	var b strings.Builder
+
+	switch shell {
+	case "bash":
+		words := make([]string, 0, len(subcommands)+len(globals))
+		for _, sub := range subcommands {
+			words = append(words, sub.name)
+		}
+		var valueFlags []string
+		for _, entry := range globals {
+			words = append(words, "-"+entry.name)
+			if !entry.isBool {
+				valueFlags = append(valueFlags, "-"+entry.name)
+			}
+		}
+
+		fmt.Fprintf(&b, "_kafka_topic_creator() {\n")
+		fmt.Fprintf(&b, "    local cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+		fmt.Fprintf(&b, "    local i command=\"\"\n")
+		fmt.Fprintf(&b, "    for ((i = 1; i < COMP_CWORD; i++)); do\n")
+		fmt.Fprintf(&b, "        case \"${COMP_WORDS[i]}\" in\n")
+		if len(valueFlags) > 0 {
+			fmt.Fprintf(&b, "            %s) ((i++)) ;;\n", strings.Join(valueFlags, "|"))
+		}
+		fmt.Fprintf(&b, "            -*) ;;\n")
+		fmt.Fprintf(&b, "            *) command=\"${COMP_WORDS[i]}\"; break ;;\n")
+		fmt.Fprintf(&b, "        esac\n")
+		fmt.Fprintf(&b, "    done\n")
+		fmt.Fprintf(&b, "    case \"$command\" in\n")
+		fmt.Fprintf(&b, "        \"\") COMPREPLY=($(compgen -W \"%s\" -- \"$cur\")) ;;\n", strings.Join(words, " "))
+		for _, sub := range subcommands {
+			names := make([]string, 0, len(sub.flags))
+			for _, entry := range sub.flags {
+				names = append(names, "-"+entry.name)
+			}
+			fmt.Fprintf(&b, "        %s) COMPREPLY=($(compgen -W \"%s\" -- \"$cur\")) ;;\n", sub.name, strings.Join(names, " "))
+		}
+		fmt.Fprintf(&b, "    esac\n")
+		fmt.Fprintf(&b, "}\n")
+		fmt.Fprintf(&b, "complete -o default -F _kafka_topic_creator %s\n", completionCommand)
+	case "zsh":
+		names := make([]string, 0, len(subcommands))
+		for _, sub := range subcommands {
+			names = append(names, sub.name)
+		}
+
+		fmt.Fprintf(&b, "#compdef %s\n\n", completionCommand)
+		fmt.Fprintf(&b, "_kafka_topic_creator() {\n")
+		fmt.Fprintf(&b, "  local state\n")
+		fmt.Fprintf(&b, "  _arguments -C \\\n")
+		for _, entry := range globals {
+			fmt.Fprintf(&b, "    %s \\\n", zshFlagSpec(entry))
+		}
+		fmt.Fprintf(&b, "    '1:command:(%s)' \\\n", strings.Join(names, " "))
+		fmt.Fprintf(&b, "    '*::arg:->args'\n")
+		fmt.Fprintf(&b, "  case $state in\n")
+		fmt.Fprintf(&b, "    args)\n")
+		fmt.Fprintf(&b, "      case $words[1] in\n")
+		for _, sub := range subcommands {
+			fmt.Fprintf(&b, "        %s)\n", sub.name)
+			fmt.Fprintf(&b, "          _arguments")
+			for _, entry := range sub.flags {
+				fmt.Fprintf(&b, " \\\n            %s", zshFlagSpec(entry))
+			}
+			fmt.Fprintf(&b, "\n          ;;\n")
+		}
+		fmt.Fprintf(&b, "      esac\n")
+		fmt.Fprintf(&b, "      ;;\n")
+		fmt.Fprintf(&b, "  esac\n")
+		fmt.Fprintf(&b, "}\n\n")
+		fmt.Fprintf(&b, "_kafka_topic_creator \"$@\"\n")
+	case "fish":
+		for _, sub := range subcommands {
+			fmt.Fprintf(&b, "complete -c %s -f -n '__fish_use_subcommand' -a %s -d '%s'\n",
+				completionCommand, sub.name, fishEscape(sub.summary))
+		}
+		for _, entry := range globals {
+			fmt.Fprintln(&b, fishFlagLine("__fish_use_subcommand", entry))
+		}
+		for _, sub := range subcommands {
+			for _, entry := range sub.flags {
+				fmt.Fprintln(&b, fishFlagLine("__fish_seen_subcommand_from "+sub.name, entry))
+			}
+		}
+	default:
+		return "", fmt.Errorf("unsupported shell '%s' (expected bash, zsh or fish)", shell)
+	}
+
+	return b.String(), nil
+}
+
+// zshFlagSpec returns the quoted _arguments spec of a flag
+func zshFlagSpec(entry completionFlag) string {
+	spec := fmt.Sprintf("'-%s[%s]", entry.name, zshEscape(entry.usage))
+	switch {
+	case entry.isBool:
+	case entry.isPath:
+		spec += ":file:_files"
+	default:
+		spec += ":value:"
+	}
+	return spec + "'"
+}
+
+// fishFlagLine returns the complete command of a flag offered when condition holds
+func fishFlagLine(condition string, entry completionFlag) string {
+	line := fmt.Sprintf("complete -c %s -n '%s' -o %s -d '%s'", completionCommand, condition, entry.name, fishEscape(entry.usage))
+	if !entry.isBool {
+		line += " -r"
+		if entry.isPath {
+			line += " -F"
+		}
+	}
+	return line
+}
+
+// zshEscape escapes characters with special meaning in _arguments specs
+func zshEscape(s string) string {
+	replacer := strings.NewReplacer("'", `'\''`, "[", `\[`, "]", `\]`, ":", `\:`)
+	return replacer.Replace(s)
+}
+
+// fishEscape escapes single quotes for fish's single-quoted strings
+func fishEscape(s string) string {
+	return strings.ReplaceAll(s, "'", "\\'")
+}
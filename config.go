@@ -2,6 +2,9 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"slices"
+	"strings"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
@@ -12,11 +15,78 @@ type KafkaConfig struct {
 	Server   string `envconfig:"KAFKA_SERVER" default:"localhost:9092"`
 	Username string `envconfig:"KAFKA_USERNAME" default:""`
 	Password string `envconfig:"KAFKA_PASSWORD" default:""`
+	ClientID string `envconfig:"KAFKA_CLIENT_ID" default:"kafka-topic-creator"`
+
+	// Credential files take precedence over the inline values above
+	UsernameFile string `envconfig:"KAFKA_USERNAME_FILE" default:""`
+	PasswordFile string `envconfig:"KAFKA_PASSWORD_FILE" default:""`
 
 	// Debug and logging configuration
 	DebugEnabled bool   `envconfig:"KAFKA_DEBUG_ENABLED" default:"false"`
 	Debug        string `envconfig:"KAFKA_DEBUG" default:""`
-	LogLevel     int    `envconfig:"KAFKA_LOG_LEVEL" default:"6"` // 6=INFO, 7=DEBUG
+	LogLevel     int    `envconfig:"KAFKA_LOG_LEVEL" default:"6"` // syslog scale, see minLogLevel
+
+	// SecurityProtocol replaces the protocol inferred from the server and credentials
+	SecurityProtocol string `envconfig:"KAFKA_SECURITY_PROTOCOL" default:""`
+
+	// BrokerAddressFamily restricts broker connections to IPv4 or IPv6 on dual-stack hosts
+	BrokerAddressFamily string `envconfig:"KAFKA_BROKER_ADDRESS_FAMILY" default:"any"`
+
+	// Connection tuning for load balancers and NAT gateways that drop idle connections;
+	// zero leaves the librdkafka default
+	SocketKeepalive      bool `envconfig:"KAFKA_SOCKET_KEEPALIVE" default:"true"`
+	ConnectionsMaxIdleMs int  `envconfig:"KAFKA_CONNECTIONS_MAX_IDLE_MS" default:"0"`
+	SocketTimeoutMs      int  `envconfig:"KAFKA_SOCKET_TIMEOUT_MS" default:"0"`
+
+	// Extra librdkafka properties applied last, as comma-separated key=value pairs
+	ExtraConfig string `envconfig:"KAFKA_EXTRA_CONFIG" default:""`
+}
+
+// KAFKA_LOG_LEVEL uses the syslog severities librdkafka logs with: 0 emergency,
+// 1 alert, 2 critical, 3 error, 4 warning, 5 notice, 6 info and 7 debug
+const (
+	minLogLevel = 0
+	maxLogLevel = 7
+)
+
+// defaultDebugCategories are the librdkafka debug contexts enabled when
+// KAFKA_DEBUG_ENABLED is set without KAFKA_DEBUG
+const defaultDebugCategories = "broker,topic,protocol"
+
+// securityProtocols lists the values accepted by KAFKA_SECURITY_PROTOCOL
+var securityProtocols = []string{"PLAINTEXT", "SSL", "SASL_PLAINTEXT", "SASL_SSL"}
+
+// brokerAddressFamilies lists the values accepted by KAFKA_BROKER_ADDRESS_FAMILY
+var brokerAddressFamilies = []string{"any", "v4", "v6"}
+
+// normalizeServers checks a comma-separated bootstrap server list and returns it in
+// the form bootstrap.servers expects, with blanks and empty entries removed
+func normalizeServers(value string) (string, error) {
+	var servers []string
+	for _, server := range strings.Split(value, ",") {
+		server = strings.TrimSpace(server)
+		if server == "" {
+			continue
+		}
+		if strings.HasPrefix(server, ":") {
+			return "", fmt.Errorf("server '%s' has no host", server)
+		}
+		servers = append(servers, server)
+	}
+	if len(servers) == 0 {
+		return "", fmt.Errorf("no bootstrap server given")
+	}
+
+	return strings.Join(servers, ","), nil
+}
+
+// DebugCategories returns the librdkafka debug contexts applied when debugging is
+// enabled: KAFKA_DEBUG, or defaultDebugCategories when it is empty
+func (c KafkaConfig) DebugCategories() string {
+	if c.Debug == "" {
+		return defaultDebugCategories
+	}
+	return c.Debug
 }
 
 // ShouldUseAuth returns true if authentication credentials are properly configured
@@ -24,10 +94,66 @@ func (c KafkaConfig) ShouldUseAuth() bool {
 	return c.Username != "" && c.Password != ""
 }
 
+// ExtraConfigEntries parses ExtraConfig into an ordered list of key/value pairs
+func (c KafkaConfig) ExtraConfigEntries() ([][2]string, error) {
+	var entries [][2]string
+	for _, pair := range strings.Split(c.ExtraConfig, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid KAFKA_EXTRA_CONFIG entry '%s': expected key=value", pair)
+		}
+		entries = append(entries, [2]string{key, strings.TrimSpace(value)})
+	}
+
+	return entries, nil
+}
+
+// envFile is the dotenv file loaded before the environment is read: .env, or the file
+// selected with -env-file or ENV_FILE
+var envFile = ".env"
+
+// loadEnvFile loads envFile if it exists; variables already set in the environment
+// take precedence over its entries
+func loadEnvFile() {
+	_ = godotenv.Load(envFile)
+}
+
+// selectEnvFile applies -env-file, falling back to ENV_FILE, e.g. .env.prod to switch
+// credential sets. Unlike the default .env, an explicitly selected file must exist.
+func selectEnvFile(path string) error {
+	if path == "" {
+		path = os.Getenv("ENV_FILE")
+	}
+	if path == "" {
+		return nil
+	}
+
+	if err := godotenv.Load(path); err != nil {
+		return fmt.Errorf("failed to load env file %s: %w", path, err)
+	}
+	envFile = path
+	return nil
+}
+
+// defaultConfigFile returns the topics config path from KAFKA_CONFIG_FILE, used when
+// the -config flag is not given
+func defaultConfigFile() string {
+	// Load the .env file if it exists so KAFKA_CONFIG_FILE can be set there too
+	loadEnvFile()
+
+	return os.Getenv("KAFKA_CONFIG_FILE")
+}
+
 // loadConfig loads configuration from .env file and environment variables
 func loadConfig() (KafkaConfig, error) {
-	// Load .env file if it exists (ignore error if file doesn't exist)
-	_ = godotenv.Load()
+	// Load the .env file (or -env-file) if it exists
+	loadEnvFile()
 
 	// Get Kafka configuration from environment
 	var config KafkaConfig
@@ -35,5 +161,130 @@ func loadConfig() (KafkaConfig, error) {
 		return config, fmt.Errorf("failed to process environment config: %w", err)
 	}
 
+	// Read credentials from files when configured
+	if config.UsernameFile != "" {
+		username, err := readSecretFile(config.UsernameFile)
+		if err != nil {
+			return config, fmt.Errorf("failed to read KAFKA_USERNAME_FILE: %w", err)
+		}
+		config.Username = username
+	}
+	if config.PasswordFile != "" {
+		password, err := readSecretFile(config.PasswordFile)
+		if err != nil {
+			return config, fmt.Errorf("failed to read KAFKA_PASSWORD_FILE: %w", err)
+		}
+		config.Password = password
+	}
+
+	if config.SecurityProtocol != "" {
+		config.SecurityProtocol = strings.ToUpper(config.SecurityProtocol)
+		if !slices.Contains(securityProtocols, config.SecurityProtocol) {
+			return config, fmt.Errorf("invalid KAFKA_SECURITY_PROTOCOL '%s' (expected %s)",
+				config.SecurityProtocol, strings.Join(securityProtocols, ", "))
+		}
+	}
+
+	server, err := normalizeServers(config.Server)
+	if err != nil {
+		return config, fmt.Errorf("invalid KAFKA_SERVER: %w", err)
+	}
+	config.Server = server
+
+	config.BrokerAddressFamily = strings.ToLower(config.BrokerAddressFamily)
+	if !slices.Contains(brokerAddressFamilies, config.BrokerAddressFamily) {
+		return config, fmt.Errorf("invalid KAFKA_BROKER_ADDRESS_FAMILY '%s' (expected %s)",
+			config.BrokerAddressFamily, strings.Join(brokerAddressFamilies, ", "))
+	}
+
+	if config.LogLevel < minLogLevel || config.LogLevel > maxLogLevel {
+		return config, fmt.Errorf("invalid KAFKA_LOG_LEVEL %d (expected %d to %d, e.g. 6 for info or 7 for debug)",
+			config.LogLevel, minLogLevel, maxLogLevel)
+	}
+
+	if config.ConnectionsMaxIdleMs < 0 {
+		return config, fmt.Errorf("invalid KAFKA_CONNECTIONS_MAX_IDLE_MS %d (expected 0 or more)", config.ConnectionsMaxIdleMs)
+	}
+	if config.SocketTimeoutMs < 0 {
+		return config, fmt.Errorf("invalid KAFKA_SOCKET_TIMEOUT_MS %d (expected 0 or more)", config.SocketTimeoutMs)
+	}
+
+	// Fail early on malformed extra properties rather than at connect time
+	if _, err := config.ExtraConfigEntries(); err != nil {
+		return config, err
+	}
+
 	return config, nil
 }
+
+// readSecretFile returns the trimmed contents of a mounted secret file
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// printEffectiveConfig prints the resolved configuration with secrets redacted
+func printEffectiveConfig(config KafkaConfig) error {
+	extraEntries, err := config.ExtraConfigEntries()
+	if err != nil {
+		return err
+	}
+
+	protocol := securityProtocol(config)
+	if config.SecurityProtocol != "" {
+		protocol += " (from KAFKA_SECURITY_PROTOCOL)"
+	}
+	for _, entry := range extraEntries {
+		if entry[0] == "security.protocol" {
+			protocol = entry[1] + " (from KAFKA_EXTRA_CONFIG)"
+		}
+	}
+
+	fmt.Fprintln(messageOut, "⚙️  Effective configuration:")
+	fmt.Fprintf(messageOut, "   Server: %s\n", config.Server)
+	fmt.Fprintf(messageOut, "   Client ID: %s\n", config.ClientID)
+	fmt.Fprintf(messageOut, "   Username: %s\n", config.Username)
+	fmt.Fprintf(messageOut, "   Password: %s\n", redact(config.Password))
+	if config.UsernameFile != "" {
+		fmt.Fprintf(messageOut, "   Username file: %s\n", config.UsernameFile)
+	}
+	if config.PasswordFile != "" {
+		fmt.Fprintf(messageOut, "   Password file: %s\n", config.PasswordFile)
+	}
+	fmt.Fprintf(messageOut, "   Debug enabled: %t\n", config.DebugEnabled)
+	if config.DebugEnabled {
+		// The categories librdkafka gets, not the raw KAFKA_DEBUG that may be empty
+		fmt.Fprintf(messageOut, "   Debug categories: %s\n", config.DebugCategories())
+	}
+	fmt.Fprintf(messageOut, "   Log level: %d\n", config.LogLevel)
+	fmt.Fprintf(messageOut, "   Security protocol: %s\n", protocol)
+	fmt.Fprintf(messageOut, "   Broker address family: %s\n", config.BrokerAddressFamily)
+	fmt.Fprintf(messageOut, "   Socket keepalive: %t\n", config.SocketKeepalive)
+	fmt.Fprintf(messageOut, "   Connections max idle: %s\n", librdkafkaMs(config.ConnectionsMaxIdleMs))
+	fmt.Fprintf(messageOut, "   Socket timeout: %s\n", librdkafkaMs(config.SocketTimeoutMs))
+	for _, entry := range extraEntries {
+		fmt.Fprintf(messageOut, "   Extra config: %s=%s\n", entry[0], redact(entry[1]))
+	}
+
+	return nil
+}
+
+// librdkafkaMs formats a millisecond setting where zero leaves the librdkafka default
+func librdkafkaMs(ms int) string {
+	if ms == 0 {
+		return "librdkafka default"
+	}
+	return fmt.Sprintf("%dms", ms)
+}
+
+// redact hides a secret value while still showing whether it is set
+func redact(value string) string {
+	if value == "" {
+		return "(not set)"
+	}
+	return "********"
+}
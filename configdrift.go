@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// Values of ManagerOptions.ConfigMode; an empty mode is treated as incremental
+const (
+	// configModeIncremental sets only the keys in the config file, leaving other
+	// overrides on the topic alone (IncrementalAlterConfigs, Kafka 2.3+)
+	configModeIncremental = "incremental"
+
+	// configModeFull replaces the topic's overrides with the keys in the config file,
+	// resetting every other key to its default (AlterConfigs)
+	configModeFull = "full"
+)
+
+// configChange is a topic config key whose value on the cluster differs from the
+// config file. A reset change removes an override the config file does not declare.
+type configChange struct {
+	name    string
+	current string
+	desired string
+	reset   bool
+}
+
+// planConfigChanges compares the config of the existing topics with their specs and
+// returns the changes per topic. In full mode, overrides missing from a spec are
+// reported as resets; in incremental mode topics without config are not described.
+func (tm *TopicManager) planConfigChanges(ctx context.Context, topicSpecs []kafka.TopicSpecification,
+	existingTopics map[string]kafka.TopicMetadata) (map[string][]configChange, error) {
+	if tm.opts.CreateOnly {
+		return nil, nil
+	}
+
+	full := tm.opts.ConfigMode == configModeFull
+	specs := make(map[string]kafka.TopicSpecification)
+	var names []string
+	for _, spec := range topicSpecs {
+		if _, exists := existingTopics[spec.Topic]; !exists || (len(spec.Config) == 0 && !full) {
+			continue
+		}
+		specs[spec.Topic] = spec
+		names = append(names, spec.Topic)
+	}
+
+	current, err := tm.describeTopicConfigs(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string][]configChange)
+	for topic, entries := range current {
+		spec := specs[topic]
+
+		for name, desired := range spec.Config {
+			if entry, ok := entries[name]; !ok || entry.Value != desired {
+				changes[topic] = append(changes[topic], configChange{
+					name:    name,
+					current: entry.Value,
+					desired: desired,
+				})
+			}
+		}
+		if full {
+			for name, entry := range entries {
+				if _, declared := spec.Config[name]; !declared && entry.Source == kafka.ConfigSourceDynamicTopic {
+					changes[topic] = append(changes[topic], configChange{
+						name:    name,
+						current: entry.Value,
+						reset:   true,
+					})
+				}
+			}
+		}
+
+		sort.Slice(changes[topic], func(i, j int) bool {
+			return changes[topic][i].name < changes[topic][j].name
+		})
+	}
+
+	return changes, nil
+}
+
+// describeTopicConfigs returns the current config entries of the named topics, keyed
+// by topic and config name, from a single DescribeConfigs request. It is the one
+// source of current topic configs for plan, sync, export and describe.
+func (tm *TopicManager) describeTopicConfigs(ctx context.Context, names []string) (map[string]map[string]kafka.ConfigEntryResult, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	resources := make([]kafka.ConfigResource, 0, len(names))
+	for _, name := range names {
+		resources = append(resources, kafka.ConfigResource{Type: kafka.ResourceTopic, Name: name})
+	}
+	results, err := tm.adminClient.DescribeConfigs(ctx, resources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe topic configs: %w", err)
+	}
+
+	configs := make(map[string]map[string]kafka.ConfigEntryResult, len(results))
+	for _, result := range results {
+		if result.Error.Code() != kafka.ErrNoError {
+			return nil, fmt.Errorf("failed to describe configs for topic '%s': %v", result.Name, result.Error)
+		}
+		configs[result.Name] = result.Config
+	}
+
+	return configs, nil
+}
+
+// getTopicConfigs returns the current config entries of a single topic
+func (tm *TopicManager) getTopicConfigs(ctx context.Context, topic string) (map[string]kafka.ConfigEntryResult, error) {
+	configs, err := tm.describeTopicConfigs(ctx, []string{topic})
+	if err != nil {
+		return nil, err
+	}
+
+	return configs[topic], nil
+}
+
+// alterTopicConfigs applies the config file's keys to an existing topic, with
+// IncrementalAlterConfigs or, in full mode, AlterConfigs
+func (tm *TopicManager) alterTopicConfigs(ctx context.Context, topicName string, desired map[string]string) error {
+	resource := kafka.ConfigResource{Type: kafka.ResourceTopic, Name: topicName}
+
+	var results []kafka.ConfigResourceResult
+	var err error
+	if tm.opts.ConfigMode == configModeFull {
+		resource.Config = kafka.StringMapToConfigEntries(desired, kafka.AlterOperationSet)
+		results, err = tm.adminClient.AlterConfigs(ctx, []kafka.ConfigResource{resource})
+	} else {
+		operations := make(map[string]kafka.AlterConfigOpType, len(desired))
+		for name := range desired {
+			operations[name] = kafka.AlterConfigOpTypeSet
+		}
+		resource.Config = kafka.StringMapToIncrementalConfigEntries(desired, operations)
+		results, err = tm.adminClient.IncrementalAlterConfigs(ctx, []kafka.ConfigResource{resource})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to alter configs for topic '%s': %w", topicName, err)
+	}
+
+	for _, result := range results {
+		if result.Error.Code() != kafka.ErrNoError {
+			return fmt.Errorf("failed to alter configs for topic '%s': %v", result.Name, result.Error)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// decodeErrorLine extracts the line number yaml.v3 puts in front of each decoding error
+var decodeErrorLine = regexp.MustCompile(`^line (\d+): `)
+
+// unknownFieldError matches the error yaml.v3 reports for fields rejected by KnownFields
+var unknownFieldError = regexp.MustCompile(`^field \S+ not found in type \S+$`)
+
+// locateDecodeErrors rewrites the errors of decoding a config file so each names the
+// topic and field it occurred in, e.g. "topic 'orders' field 'partitions' (line 5,
+// column 17): cannot unmarshal !!str `three` into int". Other errors are returned as is.
+func locateDecodeErrors(root *yaml.Node, err error) error {
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return err
+	}
+
+	messages := make([]string, 0, len(typeErr.Errors))
+	for _, message := range typeErr.Errors {
+		match := decodeErrorLine.FindStringSubmatch(message)
+		if match == nil {
+			messages = append(messages, message)
+			continue
+		}
+		line, _ := strconv.Atoi(match[1])
+		detail := strings.TrimPrefix(message, match[0])
+		if unknownFieldError.MatchString(detail) {
+			detail = "unknown field (check the spelling, or use -lenient to ignore it)"
+		}
+
+		location, ok := locateConfigLine(root, line, "")
+		if !ok {
+			messages = append(messages, message)
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("%s: %s", location, detail))
+	}
+
+	return errors.New(strings.Join(messages, "; "))
+}
+
+// locateConfigLine describes the mapping entry whose key or scalar value is on the given line.
+// topic is the name of the enclosing topic, if any.
+func locateConfigLine(node *yaml.Node, line int, topic string) (string, bool) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			if location, ok := locateConfigLine(child, line, topic); ok {
+				return location, true
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "name" {
+				topic = node.Content[i+1].Value
+			}
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			scalar := value.Kind != yaml.MappingNode && value.Kind != yaml.SequenceNode
+			if key.Line == line || (scalar && value.Line == line) {
+				position := fmt.Sprintf("(line %d, column %d)", key.Line, key.Column)
+				if topic == "" {
+					return fmt.Sprintf("field '%s' %s", key.Value, position), true
+				}
+				return fmt.Sprintf("topic '%s' field '%s' %s", topic, key.Value, position), true
+			}
+			if location, ok := locateConfigLine(value, line, topic); ok {
+				return location, true
+			}
+		}
+	}
+
+	return "", false
+}
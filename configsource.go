@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// configFetchTimeout bounds the download of a config file given as a URL
+const configFetchTimeout = 30 * time.Second
+
+// fetchedConfigs caches downloaded config files by URL, so the loaders reading the
+// same file during one run see the same content and fetch it once
+var fetchedConfigs = map[string][]byte{}
+
+// isConfigURL reports whether a -config value is an HTTP(S) URL rather than a path
+func isConfigURL(configFile string) bool {
+	return strings.HasPrefix(configFile, "http://") || strings.HasPrefix(configFile, "https://")
+}
+
+// readConfigSource returns the content of the config file at a local path or an
+// HTTP(S) URL, sending KAFKA_CONFIG_TOKEN as a bearer token when it is set (HTTPS only)
+func readConfigSource(configFile string) ([]byte, error) {
+	if !isConfigURL(configFile) {
+		data, err := os.ReadFile(configFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", configFile, err)
+		}
+		return data, nil
+	}
+
+	if data, ok := fetchedConfigs[configFile]; ok {
+		return data, nil
+	}
+
+	request, err := http.NewRequest(http.MethodGet, configFile, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config URL %s: %w", configFile, err)
+	}
+	request.Header.Set("Accept", "application/yaml, application/json, text/plain")
+
+	// Load the .env file if it exists so KAFKA_CONFIG_TOKEN can be set there too
+	loadEnvFile()
+	token := os.Getenv("KAFKA_CONFIG_TOKEN")
+	if token != "" {
+		// The token would travel in clear text over plain HTTP
+		if request.URL.Scheme != "https" {
+			return nil, fmt.Errorf("refusing to send KAFKA_CONFIG_TOKEN to %s: the config URL must use https", configFile)
+		}
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client := &http.Client{Timeout: configFetchTimeout}
+	if token != "" {
+		client.CheckRedirect = func(redirect *http.Request, via []*http.Request) error {
+			if redirect.URL.Scheme != "https" {
+				return fmt.Errorf("refusing to follow a redirect to %s without https", redirect.URL.Redacted())
+			}
+			return nil
+		}
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch config file %s: %w", configFile, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch config file %s: server returned %s", configFile, response.Status)
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", configFile, err)
+	}
+	fetchedConfigs[configFile] = data
+
+	return data, nil
+}
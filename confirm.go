@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// confirmDestructive asks the user to confirm an operation that removes topics.
+// It returns nil when the operation may proceed. When force is set the prompt is
+// skipped; when stdin is not a terminal the operation is refused instead of
+// blocking on input that will never arrive.
+func confirmDestructive(action string, topics []string, force bool) error {
+	if len(topics) == 0 || force {
+		return nil
+	}
+
+	if !isTerminal(os.Stdin) {
+		return fmt.Errorf("refusing to %s %d topics without confirmation: stdin is not a terminal (use -force to skip the prompt)",
+			action, len(topics))
+	}
+
+	// summaryOut keeps the prompt visible under -summary-only
+	fmt.Fprintf(summaryOut, "⚠️  About to %s %d topics:\n", action, len(topics))
+	for _, topic := range topics {
+		fmt.Fprintf(summaryOut, "   - %s\n", topic)
+	}
+	fmt.Fprint(summaryOut, "Proceed? [y/N]: ")
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return fmt.Errorf("%s aborted by user", action)
+	}
+}
+
+// isTerminal reports whether the file is attached to a character device (TTY)
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// TopicDescription holds the live state of a single topic
+type TopicDescription struct {
+	Name       string                 `json:"name"`
+	Partitions []PartitionDescription `json:"partitions"`
+	Configs    map[string]string      `json:"configs"` // Non-default configs only
+}
+
+// PartitionDescription holds the replica placement of a single partition
+type PartitionDescription struct {
+	ID       int32   `json:"id"`
+	Leader   int32   `json:"leader"`
+	Replicas []int32 `json:"replicas"`
+	ISR      []int32 `json:"isr"`
+}
+
+// DescribeTopic fetches the partition layout and non-default configs of a topic
+func (tm *TopicManager) DescribeTopic(ctx context.Context, topicName string) (TopicDescription, error) {
+	metadata, err := tm.getMetadata(ctx, &topicName, false)
+	if err != nil {
+		return TopicDescription{}, &ConnectionError{Err: fmt.Errorf("failed to get metadata: %w", err)}
+	}
+
+	topicMetadata, ok := metadata.Topics[topicName]
+	if !ok {
+		return TopicDescription{}, fmt.Errorf("topic '%s' not found", topicName)
+	}
+	if topicMetadata.Error.Code() != kafka.ErrNoError {
+		return TopicDescription{}, fmt.Errorf("failed to describe topic '%s': %v", topicName, topicMetadata.Error)
+	}
+
+	description := TopicDescription{
+		Name:    topicName,
+		Configs: make(map[string]string),
+	}
+	for _, partition := range topicMetadata.Partitions {
+		description.Partitions = append(description.Partitions, PartitionDescription{
+			ID:       partition.ID,
+			Leader:   partition.Leader,
+			Replicas: partition.Replicas,
+			ISR:      partition.Isrs,
+		})
+	}
+	sort.Slice(description.Partitions, func(i, j int) bool {
+		return description.Partitions[i].ID < description.Partitions[j].ID
+	})
+
+	entries, err := tm.getTopicConfigs(ctx, topicName)
+	if err != nil {
+		return TopicDescription{}, err
+	}
+	for name, entry := range entries {
+		if !entry.IsDefault {
+			description.Configs[name] = entry.Value
+		}
+	}
+
+	return description, nil
+}
+
+// printTopicDescription prints a topic description as a table or JSON
+func printTopicDescription(description TopicDescription, output string) error {
+	switch output {
+	case "", "table":
+		fmt.Fprintf(messageOut, "📄 Topic '%s'\n", description.Name)
+		fmt.Fprintf(messageOut, "   Partitions: %d\n", len(description.Partitions))
+		for _, partition := range description.Partitions {
+			fmt.Fprintf(messageOut, "   - Partition %-3d Leader: %-4d Replicas: %v ISR: %v\n",
+				partition.ID, partition.Leader, partition.Replicas, partition.ISR)
+		}
+
+		names := make([]string, 0, len(description.Configs))
+		for name := range description.Configs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fmt.Fprintf(messageOut, "   Non-default configs: %d\n", len(names))
+		for _, name := range names {
+			fmt.Fprintf(messageOut, "   - %s=%s\n", name, description.Configs[name])
+		}
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(description); err != nil {
+			return fmt.Errorf("failed to encode topic description: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown output format '%s' (expected table or json)", output)
+	}
+
+	return nil
+}
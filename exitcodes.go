@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"log"
+)
+
+// Process exit codes, stable so CI pipelines can branch on the outcome
+const (
+	exitOK              = 0 // All topics applied
+	exitFailure         = 1 // Every attempted operation failed
+	exitPartialFailure  = 2 // Some topics failed while others were applied
+	exitConfigError     = 3 // Configuration or validation error
+	exitConnectionError = 4 // Cluster unreachable
+	exitAuthError       = 5 // Authentication or authorization failed
+
+	// exitPlanChanges is returned by plan -diff-exit-code when the cluster differs
+	// from the config, in place of exitPartialFailure which plan never reports
+	exitPlanChanges = 2
+)
+
+// syncExitCode maps the outcome of SyncTopics to a process exit code
+func syncExitCode(result SyncResult, err error) int {
+	return outcomeExitCode(result.Succeeded(), result.Failed, err)
+}
+
+// outcomeExitCode maps an operation that succeeded for some topics and failed for
+// others to a process exit code
+func outcomeExitCode(succeeded, failed int, err error) int {
+	if err == nil {
+		return exitOK
+	}
+
+	var connErr *ConnectionError
+	if errors.As(err, &connErr) {
+		return authExitCode(err, exitConnectionError)
+	}
+
+	if failed > 0 && succeeded > 0 {
+		return authExitCode(err, exitPartialFailure)
+	}
+
+	return authExitCode(err, exitFailure)
+}
+
+// errorExitCode, when set, replaces the exit code of every error; plan -diff-exit-code
+// sets it to exitFailure so that its exit code 2 always means changes
+var errorExitCode int
+
+// failureExitCode returns the exit code of an error, honoring errorExitCode
+func failureExitCode(code int) int {
+	if errorExitCode != 0 {
+		return errorExitCode
+	}
+	return code
+}
+
+// exitWithError logs the formatted message and exits with the given code
+func exitWithError(code int, format string, args ...any) {
+	log.Printf(format, args...)
+	exit(failureExitCode(code))
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"gopkg.in/yaml.v3"
+)
+
+// ExportTopics captures the existing topics as a TopicsConfig that can be fed back
+// into sync. Only topic-level config overrides are exported; values inherited from
+// the broker are left out so they keep following the broker defaults.
+func (tm *TopicManager) ExportTopics(ctx context.Context) (TopicsConfig, error) {
+	existingTopics, err := tm.ListTopics(ctx)
+	if err != nil {
+		return TopicsConfig{}, err
+	}
+
+	names := make([]string, 0, len(existingTopics))
+	for _, topic := range existingTopics {
+		names = append(names, topic.Name)
+	}
+
+	var config TopicsConfig
+	if len(names) == 0 {
+		return config, nil
+	}
+
+	configs, err := tm.describeTopicConfigs(ctx, names)
+	if err != nil {
+		return TopicsConfig{}, err
+	}
+
+	overrides := make(map[string]map[string]string)
+	for topic, entries := range configs {
+		for name, entry := range entries {
+			if entry.Source != kafka.ConfigSourceDynamicTopic {
+				continue
+			}
+			if overrides[topic] == nil {
+				overrides[topic] = make(map[string]string)
+			}
+			overrides[topic][name] = entry.Value
+		}
+	}
+
+	for _, topic := range existingTopics {
+		config.Topics = append(config.Topics, TopicConfig{
+			Name:              topic.Name,
+			Partitions:        topic.Partitions,
+			ReplicationFactor: ReplicationFactor(topic.ReplicationFactor),
+			Config:            overrides[topic.Name],
+		})
+	}
+
+	return config, nil
+}
+
+// topicsConfigFromSpecs converts resolved topic specifications back into a
+// TopicsConfig, e.g. the topics a plan would create, so they can be applied later
+func topicsConfigFromSpecs(topicSpecs []kafka.TopicSpecification) TopicsConfig {
+	var config TopicsConfig
+	for _, spec := range topicSpecs {
+		config.Topics = append(config.Topics, TopicConfig{
+			Name:              spec.Topic,
+			Partitions:        spec.NumPartitions,
+			ReplicationFactor: ReplicationFactor(spec.ReplicationFactor),
+			ReplicaAssignment: spec.ReplicaAssignment,
+			Config:            spec.Config,
+		})
+	}
+	return config
+}
+
+// writeTopicsConfig writes a TopicsConfig as YAML in the format read by GetAllTopicConfigs
+func writeTopicsConfig(w io.Writer, config TopicsConfig) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(config); err != nil {
+		return fmt.Errorf("failed to write topics config: %w", err)
+	}
+
+	return encoder.Close()
+}
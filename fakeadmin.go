@@ -0,0 +1,466 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// fakeBrokerMessageMaxBytes is the message.max.bytes reported by FakeAdmin brokers,
+// the Kafka default
+const fakeBrokerMessageMaxBytes = "1048588"
+
+// FakeAdmin is an in-memory KafkaAdmin that tracks topics, partitions and configs in
+// maps. It backs -dry-run and lets sync decisions be exercised without a broker.
+type FakeAdmin struct {
+	mu      sync.Mutex
+	brokers []kafka.BrokerMetadata
+	topics  map[string]*fakeTopic
+	acls    []kafka.ACLBinding
+}
+
+// fakeTopic is the state of a single FakeAdmin topic
+type fakeTopic struct {
+	partitions []kafka.PartitionMetadata
+	configs    map[string]string
+}
+
+// NewFakeAdmin creates an empty FakeAdmin with the given number of brokers
+func NewFakeAdmin(brokerCount int) *FakeAdmin {
+	fake := &FakeAdmin{topics: make(map[string]*fakeTopic)}
+	for id := 1; id <= brokerCount; id++ {
+		fake.brokers = append(fake.brokers, kafka.BrokerMetadata{ID: int32(id), Host: "fake", Port: 9092})
+	}
+	return fake
+}
+
+// NewFakeAdminFromCluster creates a FakeAdmin holding a copy of the cluster's brokers,
+// topics and topic config overrides
+func NewFakeAdminFromCluster(ctx context.Context, admin KafkaAdmin) (*FakeAdmin, error) {
+	metadata, err := NewTopicManager(admin, ManagerOptions{}).getMetadata(ctx, nil, true)
+	if err != nil {
+		return nil, &ConnectionError{Err: fmt.Errorf("failed to get metadata: %w", err)}
+	}
+
+	fake := &FakeAdmin{
+		brokers: metadata.Brokers,
+		topics:  make(map[string]*fakeTopic),
+	}
+	resources := make([]kafka.ConfigResource, 0, len(metadata.Topics))
+	for name, topic := range metadata.Topics {
+		fake.topics[name] = &fakeTopic{partitions: topic.Partitions, configs: make(map[string]string)}
+		resources = append(resources, kafka.ConfigResource{Type: kafka.ResourceTopic, Name: name})
+	}
+	if len(resources) == 0 {
+		return fake, nil
+	}
+
+	results, err := admin.DescribeConfigs(ctx, resources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe topic configs: %w", err)
+	}
+	for _, result := range results {
+		topic, ok := fake.topics[result.Name]
+		if !ok || result.Error.Code() != kafka.ErrNoError {
+			continue
+		}
+		for name, entry := range result.Config {
+			if entry.Source == kafka.ConfigSourceDynamicTopic {
+				topic.configs[name] = entry.Value
+			}
+		}
+	}
+
+	return fake, nil
+}
+
+// GetMetadata returns the fake brokers and either all topics or the requested one
+func (f *FakeAdmin) GetMetadata(topic *string, allTopics bool, timeoutMs int) (*kafka.Metadata, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	metadata := &kafka.Metadata{
+		Brokers: f.brokers,
+		Topics:  make(map[string]kafka.TopicMetadata),
+	}
+	if len(f.brokers) > 0 {
+		metadata.OriginatingBroker = f.brokers[0]
+	}
+
+	switch {
+	case topic != nil:
+		if state, ok := f.topics[*topic]; ok {
+			metadata.Topics[*topic] = kafka.TopicMetadata{Topic: *topic, Partitions: state.partitions}
+		} else {
+			metadata.Topics[*topic] = kafka.TopicMetadata{
+				Topic: *topic,
+				Error: kafka.NewError(kafka.ErrUnknownTopicOrPart, "Broker: Unknown topic or partition", false),
+			}
+		}
+	case allTopics:
+		for name, state := range f.topics {
+			metadata.Topics[name] = kafka.TopicMetadata{Topic: name, Partitions: state.partitions}
+		}
+	}
+
+	return metadata, nil
+}
+
+// CreateTopics adds the topics that do not exist yet, using one partition and up to
+// three replicas for broker-default specs
+func (f *FakeAdmin) CreateTopics(ctx context.Context, topics []kafka.TopicSpecification,
+	options ...kafka.CreateTopicsAdminOption) ([]kafka.TopicResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	results := make([]kafka.TopicResult, 0, len(topics))
+	for _, spec := range topics {
+		result := kafka.TopicResult{Topic: spec.Topic}
+
+		partitions := spec.NumPartitions
+		if partitions == useBrokerDefault {
+			partitions = 1
+		}
+		replicationFactor := spec.ReplicationFactor
+		switch {
+		case spec.ReplicaAssignment != nil:
+			partitions, replicationFactor = len(spec.ReplicaAssignment), len(spec.ReplicaAssignment[0])
+		case replicationFactor == useBrokerDefault:
+			replicationFactor = min(3, len(f.brokers))
+		}
+
+		switch {
+		case f.topics[spec.Topic] != nil:
+			result.Error = kafka.NewError(kafka.ErrTopicAlreadyExists,
+				fmt.Sprintf("Topic '%s' already exists.", spec.Topic), false)
+		case partitions < 1:
+			result.Error = kafka.NewError(kafka.ErrInvalidPartitions, "Number of partitions must be larger than 0.", false)
+		case replicationFactor < 1 || replicationFactor > len(f.brokers):
+			result.Error = kafka.NewError(kafka.ErrInvalidReplicationFactor,
+				fmt.Sprintf("Replication factor: %d larger than available brokers: %d.", replicationFactor, len(f.brokers)), false)
+		default:
+			configs := make(map[string]string, len(spec.Config))
+			for key, value := range spec.Config {
+				configs[key] = value
+			}
+			f.topics[spec.Topic] = &fakeTopic{configs: configs}
+			f.addPartitions(f.topics[spec.Topic], partitions, replicationFactor, spec.ReplicaAssignment)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// CreatePartitions increases the partition count of existing topics
+func (f *FakeAdmin) CreatePartitions(ctx context.Context, partitions []kafka.PartitionsSpecification,
+	options ...kafka.CreatePartitionsAdminOption) ([]kafka.TopicResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	results := make([]kafka.TopicResult, 0, len(partitions))
+	for _, spec := range partitions {
+		result := kafka.TopicResult{Topic: spec.Topic}
+		state := f.topics[spec.Topic]
+
+		switch {
+		case state == nil:
+			result.Error = kafka.NewError(kafka.ErrUnknownTopicOrPart, "Broker: Unknown topic or partition", false)
+		case spec.IncreaseTo <= len(state.partitions):
+			result.Error = kafka.NewError(kafka.ErrInvalidPartitions,
+				fmt.Sprintf("Topic currently has %d partitions, which is higher than the requested %d.",
+					len(state.partitions), spec.IncreaseTo), false)
+		default:
+			replicationFactor := 1
+			if len(state.partitions) > 0 && len(state.partitions[0].Replicas) > 0 {
+				replicationFactor = len(state.partitions[0].Replicas)
+			}
+			f.addPartitions(state, spec.IncreaseTo, replicationFactor, spec.ReplicaAssignment)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// DeleteTopics removes the named topics
+func (f *FakeAdmin) DeleteTopics(ctx context.Context, topics []string,
+	options ...kafka.DeleteTopicsAdminOption) ([]kafka.TopicResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	results := make([]kafka.TopicResult, 0, len(topics))
+	for _, name := range topics {
+		result := kafka.TopicResult{Topic: name}
+		if f.topics[name] == nil {
+			result.Error = kafka.NewError(kafka.ErrUnknownTopicOrPart, "Broker: Unknown topic or partition", false)
+		} else {
+			delete(f.topics, name)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// DescribeConfigs returns the configs set on fake topics, reported as topic overrides,
+// and each broker's message.max.bytes
+func (f *FakeAdmin) DescribeConfigs(ctx context.Context, resources []kafka.ConfigResource,
+	options ...kafka.DescribeConfigsAdminOption) ([]kafka.ConfigResourceResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	results := make([]kafka.ConfigResourceResult, 0, len(resources))
+	for _, resource := range resources {
+		result := kafka.ConfigResourceResult{
+			Type:   resource.Type,
+			Name:   resource.Name,
+			Config: make(map[string]kafka.ConfigEntryResult),
+		}
+
+		switch resource.Type {
+		case kafka.ResourceTopic:
+			state := f.topics[resource.Name]
+			if state == nil {
+				result.Error = kafka.NewError(kafka.ErrUnknownTopicOrPart, "Broker: Unknown topic or partition", false)
+				break
+			}
+			for name, value := range state.configs {
+				result.Config[name] = kafka.ConfigEntryResult{Name: name, Value: value, Source: kafka.ConfigSourceDynamicTopic}
+			}
+		case kafka.ResourceBroker:
+			result.Config["message.max.bytes"] = kafka.ConfigEntryResult{
+				Name:      "message.max.bytes",
+				Value:     fakeBrokerMessageMaxBytes,
+				Source:    kafka.ConfigSourceDefault,
+				IsDefault: true,
+			}
+		default:
+			result.Error = kafka.NewError(kafka.ErrInvalidRequest,
+				fmt.Sprintf("Unsupported resource type %v", resource.Type), false)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// AlterConfigs replaces the configs of fake topics with the given entries
+func (f *FakeAdmin) AlterConfigs(ctx context.Context, resources []kafka.ConfigResource,
+	options ...kafka.AlterConfigsAdminOption) ([]kafka.ConfigResourceResult, error) {
+	return f.alterConfigs(ctx, resources, func(state *fakeTopic, entries []kafka.ConfigEntry) {
+		state.configs = make(map[string]string, len(entries))
+		for _, entry := range entries {
+			state.configs[entry.Name] = entry.Value
+		}
+	})
+}
+
+// IncrementalAlterConfigs sets or deletes the given config entries of fake topics
+func (f *FakeAdmin) IncrementalAlterConfigs(ctx context.Context, resources []kafka.ConfigResource,
+	options ...kafka.AlterConfigsAdminOption) ([]kafka.ConfigResourceResult, error) {
+	return f.alterConfigs(ctx, resources, func(state *fakeTopic, entries []kafka.ConfigEntry) {
+		for _, entry := range entries {
+			if entry.IncrementalOperation == kafka.AlterConfigOpTypeDelete {
+				delete(state.configs, entry.Name)
+			} else {
+				state.configs[entry.Name] = entry.Value
+			}
+		}
+	})
+}
+
+// alterConfigs applies apply to the state of every topic resource
+func (f *FakeAdmin) alterConfigs(ctx context.Context, resources []kafka.ConfigResource,
+	apply func(state *fakeTopic, entries []kafka.ConfigEntry)) ([]kafka.ConfigResourceResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	results := make([]kafka.ConfigResourceResult, 0, len(resources))
+	for _, resource := range resources {
+		result := kafka.ConfigResourceResult{Type: resource.Type, Name: resource.Name}
+		state := f.topics[resource.Name]
+
+		switch {
+		case resource.Type != kafka.ResourceTopic:
+			result.Error = kafka.NewError(kafka.ErrInvalidRequest,
+				fmt.Sprintf("Unsupported resource type %v", resource.Type), false)
+		case state == nil:
+			result.Error = kafka.NewError(kafka.ErrUnknownTopicOrPart, "Broker: Unknown topic or partition", false)
+		default:
+			apply(state, resource.Config)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// CreateACLs records the bindings; like Kafka, it accepts bindings of topics that do
+// not exist and bindings that were already created
+func (f *FakeAdmin) CreateACLs(ctx context.Context, aclBindings kafka.ACLBindings,
+	options ...kafka.CreateACLsAdminOption) ([]kafka.CreateACLResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	results := make([]kafka.CreateACLResult, 0, len(aclBindings))
+	for _, binding := range aclBindings {
+		if !slices.Contains(f.acls, binding) {
+			f.acls = append(f.acls, binding)
+		}
+		results = append(results, kafka.CreateACLResult{})
+	}
+
+	return results, nil
+}
+
+// ElectLeaders satisfies KafkaAdmin. The partitions of a kafka.ElectLeadersRequest are
+// not exported, so the fake elects every partition it holds that is led by another
+// replica; TopicManager hands it the partitions through ElectPartitionLeaders instead.
+func (f *FakeAdmin) ElectLeaders(ctx context.Context, electLeaderRequest kafka.ElectLeadersRequest,
+	options ...kafka.ElectLeadersAdminOption) (kafka.ElectLeadersResult, error) {
+	f.mu.Lock()
+	var partitions []kafka.TopicPartition
+	for name, state := range f.topics {
+		for _, partition := range state.partitions {
+			topic := name
+			partitions = append(partitions, kafka.TopicPartition{Topic: &topic, Partition: partition.ID})
+		}
+	}
+	f.mu.Unlock()
+
+	return f.ElectPartitionLeaders(ctx, kafka.ElectionTypePreferred, partitions)
+}
+
+// ElectPartitionLeaders moves the leader of each given partition led by another replica
+// to its preferred replica when that replica is in sync. Like Kafka it reports only the
+// partitions it elected or could not elect. Only preferred elections are supported.
+func (f *FakeAdmin) ElectPartitionLeaders(ctx context.Context, electionType kafka.ElectionType,
+	partitions []kafka.TopicPartition) (kafka.ElectLeadersResult, error) {
+	if err := ctx.Err(); err != nil {
+		return kafka.ElectLeadersResult{}, err
+	}
+	if electionType != kafka.ElectionTypePreferred {
+		return kafka.ElectLeadersResult{}, kafka.NewError(kafka.ErrInvalidArg, "FakeAdmin only supports preferred leader elections", false)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var result kafka.ElectLeadersResult
+	for _, requested := range partitions {
+		elected := kafka.TopicPartition{Topic: requested.Topic, Partition: requested.Partition}
+		partition := f.partition(*requested.Topic, requested.Partition)
+		if partition == nil {
+			elected.Error = kafka.NewError(kafka.ErrUnknownTopicOrPart, "Broker: Unknown topic or partition", false)
+			result.TopicPartitions = append(result.TopicPartitions, elected)
+			continue
+		}
+		if len(partition.Replicas) == 0 || partition.Leader == partition.Replicas[0] {
+			continue
+		}
+		if slices.Contains(partition.Isrs, partition.Replicas[0]) {
+			partition.Leader = partition.Replicas[0]
+		} else {
+			elected.Error = kafka.NewError(kafka.ErrPreferredLeaderNotAvailable, "Preferred leader was not available", false)
+		}
+		result.TopicPartitions = append(result.TopicPartitions, elected)
+	}
+
+	return result, nil
+}
+
+// MoveLeader hands the leadership of a partition to its next in-sync replica, as a
+// restart of the leader's broker would, e.g. to set up a preferred leader election
+func (f *FakeAdmin) MoveLeader(topic string, partition int32) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	state := f.partition(topic, partition)
+	if state == nil {
+		return fmt.Errorf("topic '%s' has no partition %d", topic, partition)
+	}
+	for _, replica := range state.Isrs {
+		if replica != state.Leader {
+			state.Leader = replica
+			return nil
+		}
+	}
+	return fmt.Errorf("topic '%s' partition %d has no other in-sync replica", topic, partition)
+}
+
+// partition returns a partition of a topic by ID, or nil when there is no such
+// partition; the caller holds f.mu
+func (f *FakeAdmin) partition(topic string, id int32) *kafka.PartitionMetadata {
+	state, exists := f.topics[topic]
+	if !exists {
+		return nil
+	}
+	for i := range state.partitions {
+		if state.partitions[i].ID == id {
+			return &state.partitions[i]
+		}
+	}
+	return nil
+}
+
+// Close releases nothing, the fake holds no connections
+func (f *FakeAdmin) Close() {}
+
+// addPartitions grows a topic to count partitions, placing the new partitions as
+// listed in assignment or else round-robin across the brokers; the caller holds f.mu
+func (f *FakeAdmin) addPartitions(state *fakeTopic, count, replicationFactor int, assignment [][]int32) {
+	first := len(state.partitions)
+	for id := first; id < count; id++ {
+		var replicas []int32
+		if id-first < len(assignment) {
+			replicas = assignment[id-first]
+		} else {
+			for r := 0; r < replicationFactor && len(f.brokers) > 0; r++ {
+				replicas = append(replicas, f.brokers[(id+r)%len(f.brokers)].ID)
+			}
+		}
+
+		leader := int32(-1)
+		if len(replicas) > 0 {
+			leader = replicas[0]
+		}
+		state.partitions = append(state.partitions, kafka.PartitionMetadata{
+			ID:       int32(id),
+			Leader:   leader,
+			Replicas: replicas,
+			Isrs:     replicas,
+		})
+	}
+}
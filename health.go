@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// PartitionHealthIssue describes a partition that is under-replicated or offline
+type PartitionHealthIssue struct {
+	Topic           string
+	Partition       int32
+	Leader          int32
+	Replicas        []int32
+	ISR             []int32
+	Offline         bool // No leader is available
+	UnderReplicated bool // Fewer in-sync replicas than assigned replicas
+	Missing         bool // Topic does not exist on the cluster
+}
+
+// HealthReport inspects the partitions of the given topics and returns those that
+// are offline or under-replicated, plus an entry for each topic that does not exist
+func (tm *TopicManager) HealthReport(ctx context.Context, topicSpecs []kafka.TopicSpecification) ([]PartitionHealthIssue, error) {
+	existingTopics, err := tm.existingTopicsFor(ctx, topicSpecs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get existing topics: %w", err)
+	}
+
+	var issues []PartitionHealthIssue
+	for _, spec := range topicSpecs {
+		metadata, exists := existingTopics[spec.Topic]
+		if !exists {
+			issues = append(issues, PartitionHealthIssue{Topic: spec.Topic, Missing: true})
+			continue
+		}
+
+		for _, partition := range metadata.Partitions {
+			offline := partition.Leader < 0
+			underReplicated := len(partition.Isrs) < len(partition.Replicas)
+			if !offline && !underReplicated {
+				continue
+			}
+
+			issues = append(issues, PartitionHealthIssue{
+				Topic:           spec.Topic,
+				Partition:       partition.ID,
+				Leader:          partition.Leader,
+				Replicas:        partition.Replicas,
+				ISR:             partition.Isrs,
+				Offline:         offline,
+				UnderReplicated: underReplicated,
+			})
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Topic != issues[j].Topic {
+			return issues[i].Topic < issues[j].Topic
+		}
+		return issues[i].Partition < issues[j].Partition
+	})
+
+	return issues, nil
+}
+
+// printHealthReport prints the problematic partitions found by HealthReport
+func printHealthReport(issues []PartitionHealthIssue, topicCount int) {
+	if len(issues) == 0 {
+		fmt.Fprintf(messageOut, "✅ All partitions of %d managed topics are online and fully replicated\n", topicCount)
+		return
+	}
+
+	fmt.Fprintf(messageOut, "🩺 Health report: %d issues across %d managed topics\n", len(issues), topicCount)
+	for _, issue := range issues {
+		switch {
+		case issue.Missing:
+			fmt.Fprintf(messageOut, "   - '%s': topic does not exist\n", issue.Topic)
+		case issue.Offline:
+			fmt.Fprintf(messageOut, "   - '%s' partition %d: OFFLINE (no leader) Replicas: %v ISR: %v\n",
+				issue.Topic, issue.Partition, issue.Replicas, issue.ISR)
+		default:
+			fmt.Fprintf(messageOut, "   - '%s' partition %d: under-replicated Leader: %d Replicas: %v ISR: %v\n",
+				issue.Topic, issue.Partition, issue.Leader, issue.Replicas, issue.ISR)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+//go:build integration
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/testcontainers/testcontainers-go"
+	tckafka "github.com/testcontainers/testcontainers-go/modules/kafka"
+)
+
+// integrationKafkaImage is the single-broker KRaft image the integration tests run against
+const integrationKafkaImage = "confluentinc/confluent-local:7.5.0"
+
+// startKafka runs a throwaway broker for the test and returns a TopicManager connected to it
+func startKafka(t *testing.T) *TopicManager {
+	t.Helper()
+	ctx := context.Background()
+
+	container, err := tckafka.Run(ctx, integrationKafkaImage, tckafka.WithClusterID("kafka-topic-creator"))
+	testcontainers.CleanupContainer(t, container)
+	if err != nil {
+		t.Fatalf("failed to start Kafka container: %v", err)
+	}
+
+	brokers, err := container.Brokers(ctx)
+	if err != nil {
+		t.Fatalf("failed to get broker address: %v", err)
+	}
+
+	adminClient, err := getKafkaAdmin(KafkaConfig{
+		Server:   brokers[0],
+		ClientID: "kafka-topic-creator-test",
+		LogLevel: 3,
+	})
+	if err != nil {
+		t.Fatalf("failed to create admin client: %v", err)
+	}
+	t.Cleanup(adminClient.Close)
+
+	return NewTopicManager(adminClient, ManagerOptions{Quiet: true, LogFormat: "text"})
+}
+
+// waitForPartitions polls the cluster until the topic reports the partition count,
+// since metadata may lag behind a completed admin request
+func waitForPartitions(t *testing.T, tm *TopicManager, topic string, partitions int) {
+	t.Helper()
+
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		existing, err := tm.GetExistingTopics(context.Background())
+		if err != nil {
+			t.Fatalf("failed to get existing topics: %v", err)
+		}
+		if metadata, ok := existing[topic]; ok && len(metadata.Partitions) == partitions {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("topic '%s' did not reach %d partitions", topic, partitions)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+func TestIntegrationCreateTopics(t *testing.T) {
+	tm := startKafka(t)
+	ctx := context.Background()
+	specs := []kafka.TopicSpecification{
+		{Topic: "integration.orders", NumPartitions: 3, ReplicationFactor: 1},
+		{Topic: "integration.payments", NumPartitions: 1, ReplicationFactor: 1},
+	}
+
+	result, err := tm.CreateTopics(ctx, specs)
+	if err != nil {
+		t.Fatalf("CreateTopics failed: %v", err)
+	}
+	if result.Created != 2 {
+		t.Fatalf("expected 2 created topics, got %d", result.Created)
+	}
+	waitForPartitions(t, tm, "integration.orders", 3)
+	waitForPartitions(t, tm, "integration.payments", 1)
+
+	// A second run finds both topics and changes nothing
+	result, err = tm.CreateTopics(ctx, specs)
+	if err != nil {
+		t.Fatalf("second CreateTopics failed: %v", err)
+	}
+	if result.Created != 0 || result.Unchanged != 2 {
+		t.Fatalf("expected 0 created and 2 unchanged topics, got %d and %d", result.Created, result.Unchanged)
+	}
+}
+
+func TestIntegrationSyncTopics(t *testing.T) {
+	tm := startKafka(t)
+	ctx := context.Background()
+	topic := "integration.events"
+
+	result, err := tm.SyncTopics(ctx, []kafka.TopicSpecification{{Topic: topic, NumPartitions: 1, ReplicationFactor: 1}})
+	if err != nil {
+		t.Fatalf("SyncTopics failed to create the topic: %v", err)
+	}
+	if result.Created != 1 {
+		t.Fatalf("expected 1 created topic, got %d", result.Created)
+	}
+	waitForPartitions(t, tm, topic, 1)
+
+	result, err = tm.SyncTopics(ctx, []kafka.TopicSpecification{{Topic: topic, NumPartitions: 4, ReplicationFactor: 1}})
+	if err != nil {
+		t.Fatalf("SyncTopics failed to increase partitions: %v", err)
+	}
+	if result.Updated != 1 {
+		t.Fatalf("expected 1 updated topic, got %d", result.Updated)
+	}
+	waitForPartitions(t, tm, topic, 4)
+
+	// Partitions cannot be decreased, which is reported rather than applied
+	result, err = tm.SyncTopics(ctx, []kafka.TopicSpecification{{Topic: topic, NumPartitions: 2, ReplicationFactor: 1}})
+	if err != nil {
+		t.Fatalf("SyncTopics failed on scale down: %v", err)
+	}
+	if result.CannotScaleDown != 1 || len(result.Warnings) == 0 {
+		t.Fatalf("expected a scale-down warning, got %d cannot scale down and %d warnings",
+			result.CannotScaleDown, len(result.Warnings))
+	}
+	waitForPartitions(t, tm, topic, 4)
+}
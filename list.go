@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// ListOptions controls how topics are printed by the list command
+type ListOptions struct {
+	SortBy string // name, partitions or replication
+	Limit  int    // 0 means no limit
+	Output string // table or json
+	Filter string // optional regular expression matched against topic names
+}
+
+// topicListEntry is the JSON representation of a listed topic
+type topicListEntry struct {
+	Name              string            `json:"name"`
+	Partitions        int               `json:"partitions"`
+	ReplicationFactor int               `json:"replication_factor"`
+	Config            map[string]string `json:"config,omitempty"`
+}
+
+// topicSpecDump is the JSON representation of a resolved kafka.TopicSpecification,
+// printed by -dump-specs exactly as it would be sent to the cluster
+type topicSpecDump struct {
+	Topic             string            `json:"topic"`
+	NumPartitions     int               `json:"num_partitions"`
+	ReplicationFactor int               `json:"replication_factor"`
+	ReplicaAssignment [][]int32         `json:"replica_assignment,omitempty"`
+	Config            map[string]string `json:"config,omitempty"`
+}
+
+// printTopicSpecs writes the resolved topic specifications as a JSON array
+func printTopicSpecs(w io.Writer, topicSpecs []kafka.TopicSpecification) error {
+	dumps := make([]topicSpecDump, 0, len(topicSpecs))
+	for _, spec := range topicSpecs {
+		dumps = append(dumps, topicSpecDump{
+			Topic:             spec.Topic,
+			NumPartitions:     spec.NumPartitions,
+			ReplicationFactor: spec.ReplicationFactor,
+			ReplicaAssignment: spec.ReplicaAssignment,
+			Config:            spec.Config,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(dumps); err != nil {
+		return fmt.Errorf("failed to encode topic specs: %w", err)
+	}
+	return nil
+}
+
+// printTopicList sorts, limits and prints the topic specifications
+func printTopicList(topicSpecs []kafka.TopicSpecification, opts ListOptions) error {
+	filtered, err := filterTopicSpecs(topicSpecs, opts.Filter)
+	if err != nil {
+		return err
+	}
+
+	sorted, err := sortTopicSpecs(filtered, opts.SortBy)
+	if err != nil {
+		return err
+	}
+	if opts.Limit > 0 && len(sorted) > opts.Limit {
+		sorted = sorted[:opts.Limit]
+	}
+
+	switch opts.Output {
+	case "", "table":
+		fmt.Fprintln(messageOut, "📋 Available topics:")
+		for _, ts := range sorted {
+			fmt.Fprintf(messageOut, "  %-40s Partitions: %-2d Replication: %s\n", ts.Topic, ts.NumPartitions, countLabel(ts.ReplicationFactor))
+		}
+	case "json":
+		entries := make([]topicListEntry, 0, len(sorted))
+		for _, ts := range sorted {
+			entries = append(entries, topicListEntry{
+				Name:              ts.Topic,
+				Partitions:        ts.NumPartitions,
+				ReplicationFactor: ts.ReplicationFactor,
+				Config:            ts.Config,
+			})
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(entries); err != nil {
+			return fmt.Errorf("failed to encode topic list: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown output format '%s' (expected table or json)", opts.Output)
+	}
+
+	return nil
+}
+
+// specsFromTopicInfo converts existing topics into specifications for listing
+func specsFromTopicInfo(topics []TopicInfo) []kafka.TopicSpecification {
+	specs := make([]kafka.TopicSpecification, 0, len(topics))
+	for _, topic := range topics {
+		specs = append(specs, kafka.TopicSpecification{
+			Topic:             topic.Name,
+			NumPartitions:     topic.Partitions,
+			ReplicationFactor: topic.ReplicationFactor,
+		})
+	}
+
+	return specs
+}
+
+// filterTopicSpecs keeps only the specs whose topic name matches the pattern
+func filterTopicSpecs(topicSpecs []kafka.TopicSpecification, pattern string) ([]kafka.TopicSpecification, error) {
+	if pattern == "" {
+		return topicSpecs, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter pattern '%s': %w", pattern, err)
+	}
+
+	var filtered []kafka.TopicSpecification
+	for _, spec := range topicSpecs {
+		if re.MatchString(spec.Topic) {
+			filtered = append(filtered, spec)
+		}
+	}
+
+	return filtered, nil
+}
+
+// sortTopicSpecs returns a copy of the specs sorted by the given key, ties broken by name
+func sortTopicSpecs(topicSpecs []kafka.TopicSpecification, sortBy string) ([]kafka.TopicSpecification, error) {
+	sorted := make([]kafka.TopicSpecification, len(topicSpecs))
+	copy(sorted, topicSpecs)
+
+	// Sort by name first so the stable sort below keeps ties alphabetical
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Topic < sorted[j].Topic
+	})
+
+	switch sortBy {
+	case "", "name":
+	case "partitions":
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].NumPartitions < sorted[j].NumPartitions
+		})
+	case "replication":
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].ReplicationFactor < sorted[j].ReplicationFactor
+		})
+	default:
+		return nil, fmt.Errorf("unknown sort key '%s' (expected name, partitions or replication)", sortBy)
+	}
+
+	return sorted, nil
+}
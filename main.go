@@ -2,28 +2,114 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 )
 
+// globalOptions holds the connection flags shared by every command; they may be
+// given before the command name or among the command's own flags
+type globalOptions struct {
+	server  string
+	timeout time.Duration
+	version bool
+	schema  bool
+	color   string
+	envFile string
+}
+
+// addGlobalFlags registers the shared flags, keeping any value already parsed
+func addGlobalFlags(fs *flag.FlagSet, global *globalOptions) {
+	fs.StringVar(&global.server, "server", global.server, "Kafka bootstrap server, overriding KAFKA_SERVER")
+	fs.DurationVar(&global.timeout, "timeout", global.timeout, "Bound the whole run, e.g. 2m (0 for no limit)")
+	fs.BoolVar(&global.version, "version", global.version, "Print the tool, Go and Kafka client versions and exit")
+	fs.BoolVar(&global.schema, "print-schema", global.schema, "Print the JSON Schema of the config file format and exit")
+	fs.StringVar(&global.color, "color", global.color, "Emoji decorations in the output: auto (on a terminal unless NO_COLOR is set), always or never")
+	fs.StringVar(&global.envFile, "env-file", global.envFile, "Dotenv file to load instead of .env, e.g. .env.prod (defaults to ENV_FILE)")
+}
+
 func main() {
-	// Define command-line flags
-	var (
-		listTopics = flag.Bool("list", false, "List all available topics and exit")
-		configFile = flag.String("config", "", "Path to topics configuration file (required)")
-	)
-	flag.Parse()
+	global := &globalOptions{color: colorAuto}
+	root := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	addGlobalFlags(root, global)
+
+	// Without a command the flags are those of sync, as before subcommands existed
+	name, args := "sync", os.Args[1:]
+	root.SetOutput(io.Discard)
+	switch err := root.Parse(args); {
+	case errors.Is(err, flag.ErrHelp):
+		printUsage(root)
+		return
+	case err != nil:
+		// A sync flag given without the command name
+	case root.NArg() > 0:
+		name, args = root.Arg(0), root.Args()[1:]
+	default:
+		args = nil
+	}
+
+	if global.version {
+		printVersion(os.Stdout)
+		return
+	}
+	if global.schema {
+		if err := printSchema(os.Stdout); err != nil {
+			exitWithError(exitFailure, "❌ %v", err)
+		}
+		return
+	}
 
-	// Validate that config file is provided
-	if *configFile == "" {
-		fmt.Println("❌ Error: -config flag is required")
-		fmt.Printf("Usage: %s -config <config-file.yaml> [options]\n", os.Args[0])
-		fmt.Printf("Example: %s -config topics.yaml\n", os.Args[0])
-		os.Exit(1)
+	cmd, ok := findCommand(name)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "❌ Unknown command '%s'\n\n", name)
+		printUsage(root)
+		exit(exitConfigError)
+	}
+
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	addGlobalFlags(fs, global)
+	run := cmd.setup(fs, global)
+
+	// Parse errors are reported below, followed by the full usage instead of the terse default
+	fs.SetOutput(io.Discard)
+	fs.Usage = func() {}
+	switch err := fs.Parse(args); {
+	case errors.Is(err, flag.ErrHelp):
+		printCommandUsage(fs, cmd)
+		return
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "❌ %v\n\n", err)
+		printCommandUsage(fs, cmd)
+		exit(exitConfigError)
+	}
+
+	if global.version {
+		printVersion(os.Stdout)
+		return
+	}
+	if global.schema {
+		if err := printSchema(os.Stdout); err != nil {
+			exitWithError(exitFailure, "❌ %v", err)
+		}
+		return
+	}
+	if err := setupOutput(global.color); err != nil {
+		exitWithError(exitConfigError, "❌ %v", err)
+	}
+	if err := selectEnvFile(global.envFile); err != nil {
+		exitWithError(exitConfigError, "❌ %v", err)
+	}
+	if cmd.args == "" && fs.NArg() > 0 {
+		exitWithError(exitConfigError, "❌ Unexpected arguments for %s: %v", cmd.name, fs.Args())
 	}
 
 	// Handle graceful shutdown with context cancellation
@@ -33,58 +119,143 @@ func main() {
 	// Create context that can be cancelled by signals
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	if global.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, global.timeout)
+		defer cancel()
+	}
 
 	go func() {
 		sig := <-sigChan
-		fmt.Printf("\n🛑 Received signal %v, cancelling operations...\n", sig)
+		fmt.Fprintf(messageOut, "\n🛑 Received signal %v, cancelling operations...\n", sig)
 		cancel()
 	}()
 
-	fmt.Println("🚀 Starting Kafka Topic Creation Tool")
-	fmt.Println("Press Ctrl+C to cancel...")
+	run(ctx, fs.Args())
+	flushOutput()
+}
 
-	// Load topic configurations once
-	topicConfigs, err := GetAllTopicConfigs(*configFile)
-	if err != nil {
-		log.Fatalf("❌ Failed to load topic configurations: %v", err)
+// printUsage lists the commands and global flags on stderr
+func printUsage(root *flag.FlagSet) {
+	fmt.Fprintf(os.Stderr, "Usage: %s [global flags] <command> [flags]\n\nCommands:\n", os.Args[0])
+	for _, cmd := range commands() {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", cmd.name, cmd.summary)
 	}
 
-	// Handle listing topics
-	if *listTopics {
-		fmt.Println("📋 Available topics:")
-		for _, ts := range topicConfigs {
-			fmt.Printf("  %-40s Partitions: %-2d Replication: %d\n", ts.Topic, ts.NumPartitions, ts.ReplicationFactor)
-		}
-		return
+	fmt.Fprintf(os.Stderr, "\nGlobal flags:\n")
+	root.SetOutput(os.Stderr)
+	root.PrintDefaults()
+
+	fmt.Fprintf(os.Stderr, "\nRun '%s <command> -h' for the flags of a command; without a command, sync is run.\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Example: %s sync -config topics.yaml\n", os.Args[0])
+}
+
+// printCommandUsage lists a command's flags with their descriptions on stderr, and
+// an example invocation
+func printCommandUsage(fs *flag.FlagSet, cmd command) {
+	usage := strings.TrimSpace(fmt.Sprintf("%s %s [flags] %s", os.Args[0], cmd.name, cmd.args))
+	fmt.Fprintf(os.Stderr, "Usage: %s\n\n%s\n\nFlags:\n", usage, cmd.summary)
+	fs.SetOutput(os.Stderr)
+	fs.PrintDefaults()
+
+	fmt.Fprintf(os.Stderr, "\nGlobal flags such as -server and -timeout may be given before or after the command.\n")
+	if cmd.example != "" {
+		fmt.Fprintf(os.Stderr, "Example: %s %s %s\n", os.Args[0], cmd.name, cmd.example)
 	}
+}
 
-	config, err := loadConfig()
+// runSync syncs the topics through the manager and returns the result with the
+// process exit code; cancellation by the user is not treated as a failure
+func runSync(ctx context.Context, topicManager *TopicManager, topicSpecs []kafka.TopicSpecification, strict bool) (SyncResult, int) {
+	// Sync topics with context for cancellation
+	result, err := topicManager.SyncTopics(ctx, topicSpecs)
 	if err != nil {
-		log.Fatalf("❌ Failed to load configuration: %v", err)
+		if ctx.Err() == context.Canceled {
+			fmt.Fprintln(messageOut, "✅ Topic sync cancelled by user")
+			return result, exitOK
+		}
+		log.Printf("❌ Failed to sync topics: %v", err)
+		if ctx.Err() == context.DeadlineExceeded {
+			reportTimeout(result)
+		}
+		return result, syncExitCode(result, err)
 	}
 
-	fmt.Printf("📡 Connecting to Kafka at %s\n", config.Server)
-	adminClient, err := getKafkaAdmin(config)
-	if err != nil {
-		log.Fatalf("❌ Failed to create Kafka admin client: %v", err)
+	if len(result.Unconverged) > 0 {
+		log.Printf("❌ Verification failed: %d topics did not converge", len(result.Unconverged))
+		return result, exitFailure
 	}
-	defer adminClient.Close()
 
-	topicManager := NewTopicManager(adminClient)
+	if strict && len(result.Warnings) > 0 {
+		log.Printf("❌ Strict mode: %d warnings reported during sync", len(result.Warnings))
+		return result, exitFailure
+	}
 
-	topicCount := len(topicConfigs)
-	fmt.Printf("📋 Syncing %d topics with predefined configurations\n", topicCount)
+	return result, exitOK
+}
 
-	// Sync topics with context for cancellation
-	err = topicManager.SyncTopics(ctx, topicConfigs)
+// reportTimeout summarizes a run stopped by -timeout: what was applied before the
+// deadline and which topics were still in flight when it passed
+func reportTimeout(result SyncResult) {
+	log.Printf("⏱️  Timed out: %d created, %d updated, %d unchanged before the deadline",
+		result.Created, result.Updated, result.Unchanged)
+	if len(result.FailedTopics) > 0 {
+		log.Printf("⏱️  Not completed: %s", strings.Join(result.FailedTopics, ", "))
+	}
+}
+
+// resultLinePending is set while a run with -result-line has not printed its footer
+// yet, so that a run ended early by an error still prints one with zero counts
+var resultLinePending bool
+
+// printResultLine prints the -result-line footer with the counts of the run, e.g.
+// "RESULT created=3 updated=1 unchanged=10 failed=0", for pipelines that grep stdout
+func printResultLine(result SyncResult) {
+	resultLinePending = false
+	fmt.Fprintf(resultOut, "RESULT created=%d updated=%d unchanged=%d failed=%d\n",
+		result.Created, result.Updated, result.Unchanged, result.Failed)
+}
+
+// recordCreatedTopics writes the -created-file, if requested, even after a partial
+// failure so the next pipeline step sees what was created; a write failure turns a
+// successful exit code into exitFailure
+func recordCreatedTopics(path string, created []string, code int) int {
+	if path == "" {
+		return code
+	}
+
+	if err := writeCreatedTopicsFile(path, created); err != nil {
+		log.Printf("❌ %v", err)
+		if code == exitOK {
+			return exitFailure
+		}
+		return code
+	}
+
+	fmt.Fprintf(messageOut, "📝 Recorded %d created topics in %s\n", len(created), path)
+	return code
+}
+
+// connectAdmin loads the Kafka connection configuration and creates an admin client,
+// exiting the process if either step fails. A non-empty server overrides KAFKA_SERVER.
+func connectAdmin(server string) *kafka.AdminClient {
+	config, err := loadConfig()
 	if err != nil {
-		if ctx.Err() == context.Canceled {
-			fmt.Println("✅ Topic sync cancelled by user")
-		} else {
-			log.Fatalf("❌ Failed to sync topics: %v", err)
+		exitWithError(exitConfigError, "❌ Failed to load configuration: %v", err)
+	}
+	if server != "" {
+		if config.Server, err = normalizeServers(server); err != nil {
+			exitWithError(exitConfigError, "❌ Invalid -server: %v", err)
 		}
-		return
+	}
+	if err := checkConfluentCloud(config); err != nil {
+		exitWithError(exitConfigError, "❌ %v", err)
+	}
+
+	fmt.Fprintf(statusOut, "📡 Connecting to Kafka at %s\n", config.Server)
+	adminClient, err := getKafkaAdmin(config)
+	if err != nil {
+		exitWithError(authExitCode(err, exitConnectionError), "❌ Failed to create Kafka admin client: %v", err)
 	}
 
-	fmt.Println("✅ Topic sync process completed successfully!")
+	return adminClient
 }
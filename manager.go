@@ -2,67 +2,589 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 )
 
+// KafkaAdmin is the part of the Kafka admin API used by TopicManager. It is satisfied
+// by *kafka.AdminClient and by the in-memory FakeAdmin.
+type KafkaAdmin interface {
+	GetMetadata(topic *string, allTopics bool, timeoutMs int) (*kafka.Metadata, error)
+	CreateTopics(ctx context.Context, topics []kafka.TopicSpecification,
+		options ...kafka.CreateTopicsAdminOption) ([]kafka.TopicResult, error)
+	CreatePartitions(ctx context.Context, partitions []kafka.PartitionsSpecification,
+		options ...kafka.CreatePartitionsAdminOption) ([]kafka.TopicResult, error)
+	DeleteTopics(ctx context.Context, topics []string,
+		options ...kafka.DeleteTopicsAdminOption) ([]kafka.TopicResult, error)
+	DescribeConfigs(ctx context.Context, resources []kafka.ConfigResource,
+		options ...kafka.DescribeConfigsAdminOption) ([]kafka.ConfigResourceResult, error)
+	AlterConfigs(ctx context.Context, resources []kafka.ConfigResource,
+		options ...kafka.AlterConfigsAdminOption) ([]kafka.ConfigResourceResult, error)
+	IncrementalAlterConfigs(ctx context.Context, resources []kafka.ConfigResource,
+		options ...kafka.AlterConfigsAdminOption) ([]kafka.ConfigResourceResult, error)
+	CreateACLs(ctx context.Context, aclBindings kafka.ACLBindings,
+		options ...kafka.CreateACLsAdminOption) ([]kafka.CreateACLResult, error)
+	ElectLeaders(ctx context.Context, electLeaderRequest kafka.ElectLeadersRequest,
+		options ...kafka.ElectLeadersAdminOption) (kafka.ElectLeadersResult, error)
+	Close()
+}
+
 // TopicManager handles Kafka topic operations
 type TopicManager struct {
-	adminClient *kafka.AdminClient
+	adminClient KafkaAdmin
+	opts        ManagerOptions
+}
+
+// ManagerOptions tunes how a TopicManager interacts with the cluster
+type ManagerOptions struct {
+	// IncludeInternal allows internal topics (see isInternalTopic) to be listed and synced
+	IncludeInternal bool
+
+	// BatchSize caps the number of topics sent in a single CreateTopics request (0 for no limit)
+	BatchSize int
+
+	// OpDelay is inserted between consecutive admin operations to spare busy controllers
+	OpDelay time.Duration
+
+	// CheckBrokerLimits warns before syncing when a topic's max.message.bytes exceeds
+	// the broker's message.max.bytes
+	CheckBrokerLimits bool
+
+	// Quiet suppresses per-topic informational lines and progress; failures and
+	// warnings are still printed
+	Quiet bool
+
+	// LogFormat is text or json; json emits per-topic progress as structured events
+	LogFormat string
+
+	// SummaryOnly prints nothing but the final summary line (the summary event with
+	// the json log format); see showSummaryOnly
+	SummaryOnly bool
+
+	// Output is text or jsonl; jsonl streams one JSON line per completed operation
+	// on stdout, moving all other output to stderr
+	Output string
+
+	// TargetedMetadata fetches metadata only for the configured topics, one request per
+	// topic, instead of for every topic in the cluster
+	TargetedMetadata bool
+
+	// ReplicationMax caps the replication factor chosen for replication_factor: auto
+	ReplicationMax int
+
+	// MetadataTimeout bounds each metadata request; zero falls back to
+	// defaultMetadataTimeout, or to the context deadline when there is one
+	MetadataTimeout time.Duration
+
+	// MetadataAttempts is how often a metadata request failing with a retryable error
+	// is tried, with a growing pause in between; values below 1 mean a single attempt
+	MetadataAttempts int
+
+	// CreateTimeout bounds each CreateTopics request; zero leaves only the context deadline
+	CreateTimeout time.Duration
+
+	// MinBrokers aborts a sync or create before any change when the cluster reports
+	// fewer live brokers (0 disables the check)
+	MinBrokers int
+
+	// StrictCreate fails creates of topics that already exist with a partition count
+	// or configs other than requested, instead of counting them as unchanged
+	StrictCreate bool
+
+	// Verify re-fetches the cluster state after a sync and reports the applied topics
+	// whose partitions or configs do not match their specs
+	Verify bool
+
+	// WaitForDeletion makes creates of topics still being deleted wait for the deletion
+	// to finish and retry, instead of failing
+	WaitForDeletion bool
+
+	// ConfigMode selects how config drift on existing topics is applied: incremental
+	// (the default) or full, see configModeIncremental and configModeFull
+	ConfigMode string
+
+	// CreateOnly makes sync create missing topics and leave existing ones completely
+	// alone, reporting them as skipped instead of comparing them
+	CreateOnly bool
+
+	// UpdateOnly makes sync reconcile existing topics only; missing topics are counted
+	// as skipped creations instead of being created
+	UpdateOnly bool
+
+	// AllowPartitionIncrease lets sync increase the partitions of compacted topics,
+	// whose consumers usually rely on per-key ordering
+	AllowPartitionIncrease bool
+
+	// Protected names topics that are never altered or deleted, even when configured
+	Protected []string
+
+	// ACLs are the bindings SyncTopics and CreateTopics apply once the topics exist;
+	// only bindings of the synced topics that did not fail are applied
+	ACLs []kafka.ACLBinding
+
+	// Rebalance makes SyncTopics finish with a preferred leader election for the
+	// partitions of the synced, unprotected topics led by another replica
+	Rebalance bool
 }
 
 // NewTopicManager creates a new TopicManager with the given admin client
-func NewTopicManager(adminClient *kafka.AdminClient) *TopicManager {
+func NewTopicManager(adminClient KafkaAdmin, opts ManagerOptions) *TopicManager {
 	return &TopicManager{
 		adminClient: adminClient,
+		opts:        opts,
+	}
+}
+
+// isInternalTopic reports whether a topic is managed by Kafka or Confluent itself
+// (e.g. __consumer_offsets, _confluent-metrics) and must not be touched by default
+func isInternalTopic(name string) bool {
+	return strings.HasPrefix(name, "__") || strings.HasPrefix(name, "_confluent")
+}
+
+// SyncResult summarizes the outcome of a SyncTopics run
+type SyncResult struct {
+	Created         int
+	Updated         int
+	Unchanged       int
+	CannotScaleDown int
+	Failed          int
+
+	// Skipped counts existing topics left untouched by CreateOnly
+	Skipped int
+
+	// Protected counts existing topics left untouched because they are protected
+	Protected int
+
+	// SkippedCreates counts missing topics not created because of UpdateOnly
+	SkippedCreates int
+
+	// ACLs and ACLsFailed count the ACL bindings applied and rejected
+	ACLs       int
+	ACLsFailed int
+
+	// LeadersElected and LeadersFailed count the partitions of the preferred leader
+	// election run by Rebalance
+	LeadersElected int
+	LeadersFailed  int
+
+	// Warnings lists conditions that did not fail the sync but left a topic
+	// different from its desired configuration
+	Warnings []string
+
+	// CreatedTopics names the topics newly created by this run (not pre-existing ones)
+	CreatedTopics []string
+
+	// FailedTopics names the topics whose create or update failed
+	FailedTopics []string
+
+	// Unconverged names the applied topics that Verify found different from their specs
+	Unconverged []string
+
+	// UnmanagedTopics names the non-internal topics on the cluster that are not in the
+	// config; it is left empty with TargetedMetadata, which only sees configured topics
+	UnmanagedTopics []string
+
+	// Timings records how long each phase of the run took
+	Timings PhaseTimings
+}
+
+// PhaseTimings is the time spent in each phase of a sync
+type PhaseTimings struct {
+	// Metadata covers reading the cluster state: topic metadata and topic configs
+	Metadata          time.Duration
+	Create            time.Duration
+	PartitionIncrease time.Duration
+	ConfigAlter       time.Duration
+}
+
+// String formats the timings for the summary, rounded to milliseconds
+func (t PhaseTimings) String() string {
+	return fmt.Sprintf("metadata %v, create %v, partition increases %v, config alters %v",
+		t.Metadata.Round(time.Millisecond), t.Create.Round(time.Millisecond),
+		t.PartitionIncrease.Round(time.Millisecond), t.ConfigAlter.Round(time.Millisecond))
+}
+
+// Succeeded returns the number of topics that were created, updated, already matched
+// or deliberately skipped
+func (r SyncResult) Succeeded() int {
+	return r.Created + r.Updated + r.Unchanged + r.Skipped + r.SkippedCreates + r.Protected
+}
+
+// isProtected reports whether a topic is in ManagerOptions.Protected
+func (tm *TopicManager) isProtected(name string) bool {
+	return slices.Contains(tm.opts.Protected, name)
+}
+
+// ConnectionError indicates the cluster could not be reached or queried at all
+type ConnectionError struct {
+	Err error
+}
+
+func (e *ConnectionError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
+// TopicErrors maps topic names to the Kafka error returned for them, so callers can
+// inspect exactly which topics failed and with which error codes
+type TopicErrors map[string]kafka.Error
+
+func (e TopicErrors) Error() string {
+	topics := make([]string, 0, len(e))
+	for topic := range e {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+
+	messages := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		messages = append(messages, fmt.Sprintf("'%s': %v", topic, e[topic]))
 	}
+
+	return fmt.Sprintf("%d topics failed: %s", len(e), strings.Join(messages, "; "))
 }
 
-// GetExistingTopics retrieves metadata for all existing topics
+// GetExistingTopics retrieves metadata for all existing topics, omitting internal
+// topics unless IncludeInternal is set
 func (tm *TopicManager) GetExistingTopics(ctx context.Context) (map[string]kafka.TopicMetadata, error) {
-	metadata, err := tm.adminClient.GetMetadata(nil, true, 5000)
+	metadata, err := tm.getMetadata(ctx, nil, true)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get metadata: %w", err)
+		return nil, &ConnectionError{Err: fmt.Errorf("failed to get metadata: %w", err)}
 	}
 
 	topics := make(map[string]kafka.TopicMetadata)
 	for _, topic := range metadata.Topics {
+		if isInternalTopic(topic.Topic) && !tm.opts.IncludeInternal {
+			continue
+		}
+		// KRaft clusters may still list a topic that is being deleted with this error
+		if topic.Error.Code() == kafka.ErrUnknownTopicOrPart {
+			continue
+		}
 		topics[topic.Topic] = topic
 	}
 
 	return topics, nil
 }
 
-// SyncTopics synchronizes topics to match desired configurations (creates missing, updates existing)
-func (tm *TopicManager) SyncTopics(ctx context.Context, topicSpecs []kafka.TopicSpecification) error {
-	// Get existing topics metadata
+// TopicInfo is a Kafka-independent summary of an existing topic, for callers that
+// should not depend on the confluent-kafka-go metadata types
+type TopicInfo struct {
+	Name              string
+	Partitions        int
+	ReplicationFactor int // 0 when the metadata lists no replicas yet
+	Internal          bool
+}
+
+// ListTopics returns the existing topics sorted by name; internal topics are only
+// included with IncludeInternal, as for GetExistingTopics
+func (tm *TopicManager) ListTopics(ctx context.Context) ([]TopicInfo, error) {
 	existingTopics, err := tm.GetExistingTopics(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get existing topics: %w", err)
+		return nil, err
 	}
 
-	// Track operation results
-	var topicsToCreate []kafka.TopicSpecification
-	var topicsToUpdate []topicUpdateInfo
-	var cannotScaleDown []topicScaleDownInfo
-	var unchangedCount int
+	topics := make([]TopicInfo, 0, len(existingTopics))
+	for name, metadata := range existingTopics {
+		replicationFactor, _ := replicationFactorOf(metadata)
+		topics = append(topics, TopicInfo{
+			Name:              name,
+			Partitions:        len(metadata.Partitions),
+			ReplicationFactor: replicationFactor,
+			Internal:          isInternalTopic(name),
+		})
+	}
+	sort.Slice(topics, func(i, j int) bool { return topics[i].Name < topics[j].Name })
+
+	return topics, nil
+}
+
+// replicationFactorOf returns the replica count of a topic's first partition, or false
+// when metadata carries no partitions or replicas, as during transient states such as
+// topic creation or a KRaft controller failover
+func replicationFactorOf(metadata kafka.TopicMetadata) (int, bool) {
+	if len(metadata.Partitions) == 0 || len(metadata.Partitions[0].Replicas) == 0 {
+		return 0, false
+	}
+	return len(metadata.Partitions[0].Replicas), true
+}
+
+// existingTopicsFor returns the metadata of the existing topics among the specs. With
+// TargetedMetadata only those topics are requested; otherwise all topics are fetched.
+func (tm *TopicManager) existingTopicsFor(ctx context.Context, topicSpecs []kafka.TopicSpecification) (map[string]kafka.TopicMetadata, error) {
+	if !tm.opts.TargetedMetadata {
+		return tm.GetExistingTopics(ctx)
+	}
+
+	topics := make(map[string]kafka.TopicMetadata)
+	for _, spec := range topicSpecs {
+		if isInternalTopic(spec.Topic) && !tm.opts.IncludeInternal {
+			continue
+		}
+
+		metadata, err := tm.getMetadata(ctx, &spec.Topic, false)
+		if err != nil {
+			return nil, &ConnectionError{Err: fmt.Errorf("failed to get metadata for topic '%s': %w", spec.Topic, err)}
+		}
+		topic, ok := metadata.Topics[spec.Topic]
+		switch {
+		case !ok || topic.Error.Code() == kafka.ErrUnknownTopicOrPart:
+			// The topic does not exist
+		case topic.Error.Code() != kafka.ErrNoError:
+			return nil, fmt.Errorf("failed to get metadata for topic '%s': %v", spec.Topic, topic.Error)
+		default:
+			topics[spec.Topic] = topic
+		}
+	}
+
+	return topics, nil
+}
+
+// resolveAutoReplication replaces the auto replication factor with the smaller of
+// ReplicationMax and the number of brokers in the cluster
+func (tm *TopicManager) resolveAutoReplication(ctx context.Context, topicSpecs []kafka.TopicSpecification) ([]kafka.TopicSpecification, error) {
+	if !slices.ContainsFunc(topicSpecs, func(spec kafka.TopicSpecification) bool {
+		return spec.ReplicationFactor == autoReplicationFactor
+	}) {
+		return topicSpecs, nil
+	}
+
+	metadata, err := tm.getMetadata(ctx, nil, false)
+	if err != nil {
+		return nil, &ConnectionError{Err: fmt.Errorf("failed to get metadata: %w", err)}
+	}
+	factor := min(tm.opts.ReplicationMax, len(metadata.Brokers))
+	if factor < 1 {
+		return nil, fmt.Errorf("cannot resolve replication_factor auto: %d brokers, -replication-max %d",
+			len(metadata.Brokers), tm.opts.ReplicationMax)
+	}
+	fmt.Fprintf(messageOut, "ℹ️  Using replication factor %d for auto topics (%d brokers, -replication-max %d)\n",
+		factor, len(metadata.Brokers), tm.opts.ReplicationMax)
+
+	resolved := make([]kafka.TopicSpecification, len(topicSpecs))
+	copy(resolved, topicSpecs)
+	for i := range resolved {
+		if resolved[i].ReplicationFactor == autoReplicationFactor {
+			resolved[i].ReplicationFactor = factor
+		}
+	}
+
+	return resolved, nil
+}
+
+// checkReplicaAssignments fails when a manual replica assignment names a broker that
+// is not in the cluster metadata
+func (tm *TopicManager) checkReplicaAssignments(ctx context.Context, topicSpecs []kafka.TopicSpecification) error {
+	if !slices.ContainsFunc(topicSpecs, func(spec kafka.TopicSpecification) bool {
+		return spec.ReplicaAssignment != nil
+	}) {
+		return nil
+	}
+
+	metadata, err := tm.getMetadata(ctx, nil, false)
+	if err != nil {
+		return &ConnectionError{Err: fmt.Errorf("failed to get metadata: %w", err)}
+	}
+	brokers := make(map[int32]bool, len(metadata.Brokers))
+	for _, broker := range metadata.Brokers {
+		brokers[broker.ID] = true
+	}
+
+	for _, spec := range topicSpecs {
+		for partition, replicas := range spec.ReplicaAssignment {
+			for _, broker := range replicas {
+				if !brokers[broker] {
+					return fmt.Errorf("topic '%s' replica_assignment partition %d references broker %d, which is not in the cluster",
+						spec.Topic, partition, broker)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// createRequestSpecs returns the specs as sent to CreateTopics: with a replica
+// assignment the replication factor must be left unset
+func createRequestSpecs(topicSpecs []kafka.TopicSpecification) []kafka.TopicSpecification {
+	request := make([]kafka.TopicSpecification, len(topicSpecs))
+	copy(request, topicSpecs)
+	for i := range request {
+		if request[i].ReplicaAssignment != nil {
+			request[i].ReplicationFactor = 0
+		}
+	}
+	return request
+}
+
+// defaultMetadataTimeout bounds metadata requests when the context has no deadline
+const defaultMetadataTimeout = 5 * time.Second
+
+// getMetadata requests metadata with getMetadataOnce, retrying retryable failures such
+// as a broker that is still restarting up to MetadataAttempts times
+func (tm *TopicManager) getMetadata(ctx context.Context, topic *string, allTopics bool) (*kafka.Metadata, error) {
+	for attempt := 1; ; attempt++ {
+		metadata, err := tm.getMetadataOnce(ctx, topic, allTopics)
+		// A request that ran into MetadataTimeout while ctx is still live is retried too
+		retryable := isRetryableError(err) || (errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil)
+		if err == nil || attempt >= tm.opts.MetadataAttempts || !retryable || ctx.Err() != nil {
+			return metadata, err
+		}
+
+		waitTime := time.Duration(attempt) * 1 * time.Second
+		fmt.Fprintf(messageOut, "⏳ Metadata request failed (attempt %d/%d): %v; retrying in %v...\n",
+			attempt, tm.opts.MetadataAttempts, err, waitTime)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(waitTime):
+		}
+	}
+}
+
+// getMetadataOnce wraps GetMetadata so it honors ctx: the timeout is derived from the
+// context deadline, and cancellation returns immediately instead of blocking
+func (tm *TopicManager) getMetadataOnce(ctx context.Context, topic *string, allTopics bool) (*kafka.Metadata, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if tm.opts.MetadataTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, tm.opts.MetadataTimeout)
+		defer cancel()
+	}
+
+	timeout := defaultMetadataTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+		if timeout <= 0 {
+			return nil, context.DeadlineExceeded
+		}
+	}
+
+	type metadataResult struct {
+		metadata *kafka.Metadata
+		err      error
+	}
+	done := make(chan metadataResult, 1)
+	go func() {
+		metadata, err := tm.adminClient.GetMetadata(topic, allTopics, int(timeout.Milliseconds()))
+		done <- metadataResult{metadata, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-done:
+		return result.metadata, result.err
+	}
+}
+
+// SyncPlan lists the changes SyncTopics would make to reach the desired configurations
+type SyncPlan struct {
+	ToCreate        []kafka.TopicSpecification
+	ToUpdate        []topicUpdateInfo
+	CannotScaleDown []topicScaleDownInfo
+	Unchanged       []string
+
+	// Skipped lists the existing topics left alone because of CreateOnly
+	Skipped []string
+
+	// SkippedCreates lists the missing topics left uncreated because of UpdateOnly
+	SkippedCreates []string
+
+	// Protected lists the existing topics left alone because they are protected
+	Protected []string
+
+	// Unmanaged lists the non-internal topics on the cluster that no spec declares
+	Unmanaged []string
+
+	// Warnings lists conditions found while planning, such as skipped internal topics
+	Warnings []string
+}
+
+// PlanSync compares the desired configurations with the cluster and returns the
+// resulting plan without changing anything
+func (tm *TopicManager) PlanSync(ctx context.Context, topicSpecs []kafka.TopicSpecification) (SyncPlan, error) {
+	topicSpecs, err := tm.resolveAutoReplication(ctx, topicSpecs)
+	if err != nil {
+		return SyncPlan{}, err
+	}
+	if err := tm.checkReplicaAssignments(ctx, topicSpecs); err != nil {
+		return SyncPlan{}, err
+	}
+
+	// Get existing topics metadata
+	existingTopics, err := tm.existingTopicsFor(ctx, topicSpecs)
+	if err != nil {
+		return SyncPlan{}, fmt.Errorf("failed to get existing topics: %w", err)
+	}
+
+	var plan SyncPlan
+
+	// Optional pre-flight against broker-wide limits
+	if tm.opts.CheckBrokerLimits {
+		limitWarnings, err := tm.checkMessageSizeLimits(ctx, topicSpecs)
+		if err != nil {
+			return SyncPlan{}, fmt.Errorf("failed to check broker limits: %w", err)
+		}
+		plan.Warnings = append(plan.Warnings, limitWarnings...)
+	}
+
+	configChanges, err := tm.planConfigChanges(ctx, topicSpecs, existingTopics)
+	if err != nil {
+		return SyncPlan{}, err
+	}
 
 	// Analyze each desired topic
 	for _, spec := range topicSpecs {
+		// Internal topics are hidden from existingTopics, so without this guard they'd look missing
+		if isInternalTopic(spec.Topic) && !tm.opts.IncludeInternal {
+			fmt.Fprintf(messageOut, "⚠️  Skipping internal topic '%s' (use -include-internal to manage it)\n", spec.Topic)
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("internal topic '%s' skipped", spec.Topic))
+			continue
+		}
+
 		existing, exists := existingTopics[spec.Topic]
 
 		if !exists {
 			// Topic doesn't exist - add to creation list
-			topicsToCreate = append(topicsToCreate, spec)
+			if tm.opts.UpdateOnly {
+				plan.SkippedCreates = append(plan.SkippedCreates, spec.Topic)
+			} else {
+				plan.ToCreate = append(plan.ToCreate, spec)
+			}
+			continue
+		}
+		if tm.isProtected(spec.Topic) {
+			fmt.Fprintf(messageOut, "🛡️  Skipping protected topic '%s': it is never altered\n", spec.Topic)
+			plan.Protected = append(plan.Protected, spec.Topic)
+			continue
+		}
+		if tm.opts.CreateOnly {
+			plan.Skipped = append(plan.Skipped, spec.Topic)
 			continue
 		}
 
 		// Topic exists - check if updates are needed
 		currentPartitions := len(existing.Partitions)
+		if currentPartitions == 0 {
+			// Planning against an empty partition list would request a bogus increase
+			fmt.Fprintf(messageOut, "⚠️  Topic '%s' reports no partitions yet (metadata still settling), skipping it this run\n", spec.Topic)
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("topic '%s' reported no partitions and was skipped", spec.Topic))
+			continue
+		}
 		needsUpdate := false
 		updateInfo := topicUpdateInfo{
 			topic:   spec.Topic,
@@ -71,83 +593,300 @@ func (tm *TopicManager) SyncTopics(ctx context.Context, topicSpecs []kafka.Topic
 		}
 
 		// Check partition changes
-		if spec.NumPartitions > currentPartitions {
+		switch {
+		case spec.NumPartitions == useBrokerDefault:
+			// Any existing partition count satisfies a broker-default spec
+		case spec.NumPartitions > currentPartitions:
 			// Need to increase partitions
 			needsUpdate = true
 			updateInfo.needsPartitionIncrease = true
-		} else if spec.NumPartitions < currentPartitions {
+		case spec.NumPartitions < currentPartitions:
 			// Cannot decrease partitions - report this
-			cannotScaleDown = append(cannotScaleDown, topicScaleDownInfo{
+			plan.CannotScaleDown = append(plan.CannotScaleDown, topicScaleDownInfo{
 				topic:             spec.Topic,
 				currentPartitions: currentPartitions,
 				desiredPartitions: spec.NumPartitions,
 			})
 		}
 
+		// Check config drift
+		if changes := configChanges[spec.Topic]; len(changes) > 0 {
+			needsUpdate = true
+			updateInfo.configChanges = changes
+		}
+
 		// Check replication factor changes (more complex, for now just report)
-		if len(existing.Partitions) > 0 && int32(spec.ReplicationFactor) != existing.Partitions[0].Replicas[0] {
-			// This would require more complex broker reassignment
-			// For now, we'll note it but not implement
-			fmt.Printf("⚠️  Topic '%s' replication factor change not yet implemented\n", spec.Topic)
+		if spec.ReplicationFactor > 0 {
+			currentReplication, known := replicationFactorOf(existing)
+			switch {
+			case !known:
+				// Partitions without replicas are reported while a topic is rebalancing
+				fmt.Fprintf(messageOut, "⚠️  Could not determine replication factor of topic '%s', skipping the comparison\n", spec.Topic)
+			case currentReplication != spec.ReplicationFactor:
+				// This would require more complex broker reassignment
+				// For now, we'll note it but not implement
+				fmt.Fprintf(messageOut, "⚠️  Topic '%s' replication factor change not yet implemented\n", spec.Topic)
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("topic '%s' replication factor change not yet implemented", spec.Topic))
+			}
 		}
 
 		if needsUpdate {
-			topicsToUpdate = append(topicsToUpdate, updateInfo)
-		} else if spec.NumPartitions == currentPartitions {
-			fmt.Printf("ℹ️  Topic '%s' already matches desired configuration\n", spec.Topic)
-			unchangedCount++
+			plan.ToUpdate = append(plan.ToUpdate, updateInfo)
+		} else if spec.NumPartitions == currentPartitions || spec.NumPartitions == useBrokerDefault {
+			plan.Unchanged = append(plan.Unchanged, spec.Topic)
+		}
+	}
+
+	if err := tm.guardPartitionIncreases(ctx, &plan); err != nil {
+		return SyncPlan{}, err
+	}
+
+	if !tm.opts.TargetedMetadata {
+		plan.Unmanaged = unmanagedTopics(topicSpecs, existingTopics)
+	}
+
+	return plan, nil
+}
+
+// SyncTopics synchronizes topics to match desired configurations (creates missing, updates existing)
+func (tm *TopicManager) SyncTopics(ctx context.Context, topicSpecs []kafka.TopicSpecification) (SyncResult, error) {
+	if err := tm.checkMinBrokers(ctx); err != nil {
+		return SyncResult{}, err
+	}
+
+	var timings PhaseTimings
+	started := time.Now()
+	plan, err := tm.PlanSync(ctx, topicSpecs)
+	timings.Metadata = time.Since(started)
+	if err != nil {
+		return SyncResult{}, err
+	}
+
+	topicsToCreate := plan.ToCreate
+	topicsToUpdate := plan.ToUpdate
+	cannotScaleDown := plan.CannotScaleDown
+	warnings := plan.Warnings
+	unchangedCount := len(plan.Unchanged)
+
+	if !tm.opts.Quiet {
+		for _, topic := range plan.Skipped {
+			fmt.Fprintf(messageOut, "⏭️  Skipping existing topic '%s' (-create-only)\n", topic)
+		}
+		for _, topic := range plan.SkippedCreates {
+			fmt.Fprintf(messageOut, "⏭️  Not creating missing topic '%s' (-update-only)\n", topic)
 		}
 	}
 
 	// Execute operations
 	createdCount, updatedCount, failedCount := 0, 0, 0
+	var createdTopics, failedTopics []string
 
 	// Create missing topics
 	if len(topicsToCreate) > 0 {
-		fmt.Printf("📋 Creating %d new topics...\n", len(topicsToCreate))
-		err := tm.createTopicsFromSpecs(ctx, topicsToCreate)
+		fmt.Fprintf(messageOut, "📋 Creating %d new topics...\n", len(topicsToCreate))
+		started := time.Now()
+		created, err := tm.createTopicsFromSpecs(ctx, topicsToCreate, messageOut)
+		timings.Create = time.Since(started)
+		createdTopics = created
+		createdCount = len(created)
+		var failed []string
 		if err != nil {
-			fmt.Printf("❌ Failed to create topics: %v\n", err)
-			failedCount += len(topicsToCreate)
-		} else {
-			createdCount = len(topicsToCreate)
+			fmt.Fprintf(messageOut, "❌ Failed to create topics: %v\n", err)
+
+			// Only the topics named in TopicErrors failed
+			failed = failedCreates(topicsToCreate, err)
+			failedTopics = append(failedTopics, failed...)
+			failedCount += len(failed)
 		}
+		// Topics created by someone else since the plan already exist; like CreateTopics,
+		// count them as unchanged
+		unchangedCount += len(topicsToCreate) - createdCount - len(failed)
 	}
 
 	// Update existing topics
 	if len(topicsToUpdate) > 0 {
-		fmt.Printf("🔄 Updating %d existing topics...\n", len(topicsToUpdate))
+		fmt.Fprintf(messageOut, "🔄 Updating %d existing topics...\n", len(topicsToUpdate))
+		progress := newProgressReporter("update", len(topicsToUpdate), tm.opts)
+
+		// All partition increases go in one request; its per-topic failures fail the update
+		started := time.Now()
+		partitionFailures, increased := tm.increasePartitions(ctx, topicsToUpdate)
+		timings.PartitionIncrease = time.Since(started)
+
 		for _, update := range topicsToUpdate {
-			if update.needsPartitionIncrease {
-				err := tm.increaseTopicPartitions(ctx, update.topic, update.desired.NumPartitions)
-				if err != nil {
-					fmt.Printf("❌ Failed to update partitions for topic '%s': %v\n", update.topic, err)
-					failedCount++
-				} else {
-					fmt.Printf("✅ Successfully updated partitions for topic '%s'\n", update.topic)
-					updatedCount++
+			var err error
+			if failure, failed := partitionFailures[update.topic]; failed {
+				err = fmt.Errorf("failed to increase partitions for topic '%s': %v", update.topic, failure)
+			} else if len(update.configChanges) > 0 {
+				if increased > 0 || updatedCount+failedCount > 0 {
+					tm.waitOpDelay(ctx)
 				}
+				started := time.Now()
+				err = tm.alterTopicConfigs(ctx, update.topic, update.desired.Config)
+				timings.ConfigAlter += time.Since(started)
+			}
+			if err != nil {
+				progress.failed(update.topic, err,
+					fmt.Sprintf("❌ Failed to update topic '%s': %v", update.topic, err))
+				failedTopics = append(failedTopics, update.topic)
+				failedCount++
+			} else {
+				progress.succeeded(update.topic, "updated",
+					fmt.Sprintf("✅ Successfully updated %s for topic '%s'", update.describe(), update.topic))
+				updatedCount++
 			}
 		}
 	}
 
+	// ACLs are applied once their topics exist
+	acls := aclsForTopics(tm.opts.ACLs, topicSpecs, failedTopics)
+	aclsApplied, aclErr := tm.CreateACLs(ctx, acls)
+
+	var electErr error
+	var leadersElected, leadersFailed int
+	if tm.opts.Rebalance {
+		leadersElected, leadersFailed, electErr = tm.RebalanceLeaders(ctx, topicSpecs, failedTopics)
+	}
+
 	// Report topics that cannot be scaled down
 	if len(cannotScaleDown) > 0 {
-		fmt.Printf("⚠️  %d topics cannot be scaled down (Kafka limitation):\n", len(cannotScaleDown))
+		fmt.Fprintf(messageOut, "⚠️  %d topics cannot be scaled down (Kafka limitation):\n", len(cannotScaleDown))
 		for _, info := range cannotScaleDown {
-			fmt.Printf("   - '%s': %d → %d partitions\n", info.topic, info.currentPartitions, info.desiredPartitions)
+			fmt.Fprintf(messageOut, "   - '%s': %d → %d partitions\n", info.topic, info.currentPartitions, info.desiredPartitions)
+			warnings = append(warnings, fmt.Sprintf("topic '%s' cannot be scaled down from %d to %d partitions",
+				info.topic, info.currentPartitions, info.desiredPartitions))
 		}
 	}
 
-	// Print summary
-	fmt.Printf("📊 Sync Summary: %d created, %d updated, %d unchanged, %d cannot scale down, %d failed\n",
-		createdCount, updatedCount, unchangedCount, len(cannotScaleDown), failedCount)
+	result := SyncResult{
+		Created:         createdCount,
+		Updated:         updatedCount,
+		Unchanged:       unchangedCount,
+		CannotScaleDown: len(cannotScaleDown),
+		Failed:          failedCount,
+		Skipped:         len(plan.Skipped),
+		SkippedCreates:  len(plan.SkippedCreates),
+		Protected:       len(plan.Protected),
+		ACLs:            aclsApplied,
+		ACLsFailed:      len(acls) - aclsApplied,
+		LeadersElected:  leadersElected,
+		LeadersFailed:   leadersFailed,
+		Warnings:        warnings,
+		CreatedTopics:   createdTopics,
+		FailedTopics:    failedTopics,
+		UnmanagedTopics: plan.Unmanaged,
+		Timings:         timings,
+	}
+	tm.printSyncSummary(result, plan.Unchanged)
+
+	if tm.opts.Verify {
+		unconverged, err := tm.verifyTopics(ctx, verificationSpecs(plan, topicSpecs, failedTopics))
+		if err != nil {
+			return result, fmt.Errorf("failed to verify topics: %w", err)
+		}
+		result.Unconverged = unconverged
+	}
 
 	if failedCount > 0 {
-		return fmt.Errorf("some operations failed: %d failures", failedCount)
+		return result, fmt.Errorf("some operations failed: %d failures", failedCount)
+	}
+	if aclErr != nil {
+		return result, fmt.Errorf("failed to apply ACLs: %w", aclErr)
+	}
+	if electErr != nil {
+		return result, fmt.Errorf("failed to rebalance partition leaders: %w", electErr)
 	}
 
-	return nil
+	return result, nil
+}
+
+// summaryWriter returns where the final summary lines go: summaryOut, or nowhere
+// when SummaryOnly leaves the output to the json summary event
+func summaryWriter(opts ManagerOptions) io.Writer {
+	if opts.SummaryOnly && opts.LogFormat == "json" {
+		return io.Discard
+	}
+	return summaryOut
+}
+
+// printSyncSummary prints the outcome of a sync. A run that changed nothing gets a
+// single line instead of one "already matches" line per topic.
+func (tm *TopicManager) printSyncSummary(result SyncResult, unchanged []string) {
+	if result.Created+result.Updated+result.Failed+result.CannotScaleDown == 0 {
+		fmt.Fprintf(summaryWriter(tm.opts), "✅ Nothing to do: %s\n", nothingToDoDetail(result))
+		if !tm.opts.Quiet {
+			fmt.Fprintln(messageOut, "💡 Run the plan command to inspect the topics without applying anything")
+		}
+	} else {
+		if !tm.opts.Quiet {
+			for _, topic := range unchanged {
+				fmt.Fprintf(messageOut, "ℹ️  Topic '%s' already matches desired configuration\n", topic)
+			}
+		}
+		fmt.Fprintf(summaryWriter(tm.opts), "📊 Sync Summary: %d created, %d updated, %d unchanged, %d cannot scale down, %d failed\n",
+			result.Created, result.Updated, result.Unchanged, result.CannotScaleDown, result.Failed)
+		if result.Skipped > 0 {
+			fmt.Fprintf(messageOut, "⏭️  %d existing topics skipped (-create-only)\n", result.Skipped)
+		}
+	}
+	if result.SkippedCreates > 0 {
+		fmt.Fprintf(messageOut, "⏭️  %d missing topics not created (-update-only)\n", result.SkippedCreates)
+	}
+	if result.Protected > 0 {
+		fmt.Fprintf(messageOut, "🛡️  %d protected topics left untouched\n", result.Protected)
+	}
+	if result.ACLs+result.ACLsFailed > 0 {
+		fmt.Fprintf(messageOut, "🔐 ACLs: %d applied, %d failed\n", result.ACLs, result.ACLsFailed)
+	}
+	if result.LeadersElected+result.LeadersFailed > 0 {
+		fmt.Fprintf(messageOut, "⚖️  Leaders: %d elected, %d failed\n", result.LeadersElected, result.LeadersFailed)
+	}
+	fmt.Fprintf(messageOut, "⏱️  Timings: %s\n", result.Timings)
+	if tm.opts.LogFormat == "json" {
+		emitSummaryEvent(result)
+	}
+
+	if !tm.opts.Quiet && len(result.UnmanagedTopics) > 0 {
+		fmt.Fprintf(messageOut, "🔍 %d unmanaged topics exist on the cluster but not in the config:\n", len(result.UnmanagedTopics))
+		for _, topic := range result.UnmanagedTopics {
+			fmt.Fprintf(messageOut, "   - %s\n", topic)
+		}
+	}
+}
+
+// nothingToDoDetail describes a sync that changed nothing, e.g. "all 12 topics already
+// match the configuration". "all" is left out when protected topics or missing topics
+// skipped by -update-only were not compared, and they are counted on their own lines.
+func nothingToDoDetail(result SyncResult) string {
+	count, state := result.Unchanged, "already match the configuration"
+	if result.Skipped > 0 {
+		count, state = result.Skipped, "already exist (-create-only)"
+	}
+	if count == 0 {
+		return "no topic needs to be created or updated"
+	}
+	if result.SkippedCreates+result.Protected > 0 {
+		return fmt.Sprintf("%d topics %s", count, state)
+	}
+	return fmt.Sprintf("all %d topics %s", count, state)
+}
+
+// unmanagedTopics returns the sorted names of the non-internal existing topics that
+// no spec declares
+func unmanagedTopics(topicSpecs []kafka.TopicSpecification, existingTopics map[string]kafka.TopicMetadata) []string {
+	declared := make(map[string]bool, len(topicSpecs))
+	for _, spec := range topicSpecs {
+		declared[spec.Topic] = true
+	}
+
+	var unmanaged []string
+	for name := range existingTopics {
+		if !declared[name] && !isInternalTopic(name) {
+			unmanaged = append(unmanaged, name)
+		}
+	}
+	sort.Strings(unmanaged)
+	return unmanaged
 }
 
 // Helper types for sync operations
@@ -156,6 +895,18 @@ type topicUpdateInfo struct {
 	current                kafka.TopicMetadata
 	desired                kafka.TopicSpecification
 	needsPartitionIncrease bool
+	configChanges          []configChange
+}
+
+// describe names what the update changes, e.g. "partitions and configs"
+func (u topicUpdateInfo) describe() string {
+	switch {
+	case u.needsPartitionIncrease && len(u.configChanges) > 0:
+		return "partitions and configs"
+	case u.needsPartitionIncrease:
+		return "partitions"
+	}
+	return "configs"
 }
 
 type topicScaleDownInfo struct {
@@ -164,103 +915,539 @@ type topicScaleDownInfo struct {
 	desiredPartitions int
 }
 
-// CreateTopics creates topics with predefined configurations using the admin client with retry logic
-func (tm *TopicManager) CreateTopics(ctx context.Context, topicSpecs []kafka.TopicSpecification) error {
-	return tm.createTopicsFromSpecs(ctx, topicSpecs)
+// CreateTopics creates missing topics with predefined configurations using the admin
+// client with retry logic; existing topics are left untouched and counted as unchanged
+func (tm *TopicManager) CreateTopics(ctx context.Context, topicSpecs []kafka.TopicSpecification) (SyncResult, error) {
+	if err := tm.checkMinBrokers(ctx); err != nil {
+		return SyncResult{}, err
+	}
+	topicSpecs, err := tm.resolveAutoReplication(ctx, topicSpecs)
+	if err != nil {
+		return SyncResult{}, err
+	}
+	if err := tm.checkReplicaAssignments(ctx, topicSpecs); err != nil {
+		return SyncResult{}, err
+	}
+
+	var result SyncResult
+	var topicsToCreate []kafka.TopicSpecification
+	for _, spec := range topicSpecs {
+		if isInternalTopic(spec.Topic) && !tm.opts.IncludeInternal {
+			fmt.Fprintf(messageOut, "⚠️  Skipping internal topic '%s' (use -include-internal to manage it)\n", spec.Topic)
+			result.Warnings = append(result.Warnings, fmt.Sprintf("internal topic '%s' skipped", spec.Topic))
+			continue
+		}
+		topicsToCreate = append(topicsToCreate, spec)
+	}
+	if len(topicsToCreate) == 0 {
+		return result, nil
+	}
+
+	created, err := tm.createTopicsFromSpecs(ctx, topicsToCreate, summaryWriter(tm.opts))
+	result.Created = len(created)
+	result.CreatedTopics = created
+
+	if err != nil {
+		result.FailedTopics = failedCreates(topicsToCreate, err)
+		result.Failed = len(result.FailedTopics)
+	}
+	result.Unchanged = len(topicsToCreate) - result.Created - result.Failed
+
+	acls := aclsForTopics(tm.opts.ACLs, topicsToCreate, result.FailedTopics)
+	applied, aclErr := tm.CreateACLs(ctx, acls)
+	result.ACLs, result.ACLsFailed = applied, len(acls)-applied
+	if err == nil && aclErr != nil {
+		err = fmt.Errorf("failed to apply ACLs: %w", aclErr)
+	}
+
+	return result, err
+}
+
+// failedCreates returns the topics a createTopicsFromSpecs error reports as failed:
+// those named in its TopicErrors, which include the topics of failed requests, or
+// every topic when the error carries no per-topic detail
+func failedCreates(topicSpecs []kafka.TopicSpecification, err error) []string {
+	var topicErrs TopicErrors
+	if errors.As(err, &topicErrs) {
+		failed := make([]string, 0, len(topicErrs))
+		for topic := range topicErrs {
+			failed = append(failed, topic)
+		}
+		sort.Strings(failed)
+		return failed
+	}
+
+	failed := make([]string, 0, len(topicSpecs))
+	for _, spec := range topicSpecs {
+		failed = append(failed, spec.Topic)
+	}
+	return failed
+}
+
+// DeleteTopics deletes the named topics and returns the names of those deleted;
+// topics that no longer exist are skipped, other failures are reported through TopicErrors
+func (tm *TopicManager) DeleteTopics(ctx context.Context, topics []string) ([]string, error) {
+	topics = slices.DeleteFunc(slices.Clone(topics), func(topic string) bool {
+		if tm.isProtected(topic) {
+			fmt.Fprintf(messageOut, "🛡️  Refusing to delete protected topic '%s'\n", topic)
+			return true
+		}
+		return false
+	})
+	if len(topics) == 0 {
+		return nil, nil
+	}
+
+	results, err := tm.adminClient.DeleteTopics(ctx, topics, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete topics: %w", err)
+	}
+
+	var deleted []string
+	failures := make(TopicErrors)
+	progress := newProgressReporter("delete", len(topics), tm.opts)
+	for _, result := range results {
+		switch result.Error.Code() {
+		case kafka.ErrNoError:
+			progress.succeeded(result.Topic, "deleted",
+				fmt.Sprintf("🗑️  Successfully deleted topic '%s'", result.Topic))
+			deleted = append(deleted, result.Topic)
+		case kafka.ErrUnknownTopicOrPart:
+			progress.succeeded(result.Topic, "missing",
+				fmt.Sprintf("ℹ️  Topic '%s' does not exist", result.Topic))
+		default:
+			progress.failed(result.Topic, result.Error,
+				fmt.Sprintf("❌ Failed to delete topic '%s': %v", result.Topic, result.Error))
+			failures[result.Topic] = result.Error
+		}
+	}
+
+	fmt.Fprintf(summaryWriter(tm.opts), "📊 Topic deletion summary: %d deleted, %d errors\n", len(deleted), len(failures))
+
+	if len(failures) > 0 {
+		return deleted, failures
+	}
+
+	return deleted, nil
+}
+
+// createTopicsFromSpecs creates topics from specifications in batches of BatchSize,
+// retrying each batch independently, and prints one aggregated summary to summary.
+// Failed topics are reported through a TopicErrors joined with any request-level
+// errors. It returns the names of the topics that were newly created.
+func (tm *TopicManager) createTopicsFromSpecs(ctx context.Context, topicSpecs []kafka.TopicSpecification, summary io.Writer) ([]string, error) {
+	batches := chunkTopicSpecs(topicSpecs, tm.opts.BatchSize)
+
+	var total createBatchCounts
+	var errs []error
+	failures := make(TopicErrors)
+	progress := newProgressReporter("create", len(topicSpecs), tm.opts)
+
+	for i, batch := range batches {
+		if i > 0 {
+			tm.waitOpDelay(ctx)
+		}
+		if len(batches) > 1 {
+			fmt.Fprintf(messageOut, "📦 Creating batch %d/%d (%d topics)...\n", i+1, len(batches), len(batch))
+		}
+
+		counts, err := tm.createTopicBatch(ctx, batch, failures, progress, tm.opts.WaitForDeletion)
+		total.created = append(total.created, counts.created...)
+		total.exists += counts.exists
+		total.pendingDeletion = append(total.pendingDeletion, counts.pendingDeletion...)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// With WaitForDeletion, topics still being deleted are created once the deletion completes
+	if len(total.pendingDeletion) > 0 {
+		var ready []kafka.TopicSpecification
+		for _, spec := range total.pendingDeletion {
+			fmt.Fprintf(messageOut, "⏳ Waiting for the pending deletion of topic '%s' to finish...\n", spec.Topic)
+			if err := tm.waitForDeletion(ctx, spec.Topic); err != nil {
+				failures[spec.Topic] = requestError(err)
+				progress.failed(spec.Topic, err, fmt.Sprintf("❌ Failed to create topic '%s': %v", spec.Topic, err))
+				continue
+			}
+			ready = append(ready, spec)
+		}
+
+		if len(ready) > 0 {
+			counts, err := tm.createTopicBatch(ctx, ready, failures, progress, false)
+			total.created = append(total.created, counts.created...)
+			total.exists += counts.exists
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	// Print summary
+	fmt.Fprintf(summary, "📊 Topic creation summary: %d created, %d already exist, %d errors\n",
+		len(total.created), total.exists, len(failures))
+
+	if len(failures) > 0 {
+		errs = append(errs, failures)
+	}
+
+	return total.created, errors.Join(errs...)
 }
 
-// createTopicsFromSpecs creates topics from specifications with retry logic
-func (tm *TopicManager) createTopicsFromSpecs(ctx context.Context, topicSpecs []kafka.TopicSpecification) error {
-	topicCount := len(topicSpecs)
+// createBatchCounts tallies the successful per-topic outcomes of a create request
+type createBatchCounts struct {
+	created []string
+	exists  int
 
+	// pendingDeletion holds the topics left for a retry because they are still being deleted
+	pendingDeletion []kafka.TopicSpecification
+}
+
+// createTopicBatch issues a single CreateTopics request with retry logic, recording
+// per-topic failures in failures and reporting each completed topic to progress.
+// Topics still being deleted are failures unless waitPending defers them to the caller.
+// Topics whose result timed out while the controller was busy are retried on their own;
+// every other per-topic error is final. Controller-busy errors, of the request or of
+// single topics, are retried with the longer backoff of waitControllerBusy without
+// using up an attempt.
+func (tm *TopicManager) createTopicBatch(ctx context.Context, topicSpecs []kafka.TopicSpecification,
+	failures TopicErrors, progress *progressReporter, waitPending bool) (createBatchCounts, error) {
 	// Retry logic for connection issues
 	maxRetries := 2
 	var lastErr error
+	var counts createBatchCounts
 
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		fmt.Printf("Attempting to create topics (attempt %d/%d)...\n", attempt, maxRetries)
+	for attempt, busyRetries := 1, 0; attempt <= maxRetries; attempt++ {
+		if !tm.opts.Quiet {
+			fmt.Fprintf(messageOut, "Attempting to create topics (attempt %d/%d)...\n", attempt, maxRetries)
+		}
 
 		// Create topics with timeout
-		results, err := tm.adminClient.CreateTopics(ctx, topicSpecs, nil)
+		results, err := tm.createTopicsRequest(ctx, topicSpecs)
 		if err != nil {
 			lastErr = fmt.Errorf("failed to create topics on attempt %d: %w", attempt, err)
 			log.Printf("Connection error: %v", err)
 
+			if isControllerBusy(err) && busyRetries < controllerBusyRetries {
+				busyRetries++
+				if err := waitControllerBusy(ctx, err, busyRetries); err != nil {
+					lastErr = err
+					break
+				}
+				attempt--
+				continue
+			}
+
 			// Check if it's a connection error that we should retry
 			if attempt < maxRetries && isRetryableError(err) {
 				waitTime := time.Duration(attempt) * 1 * time.Second
-				fmt.Printf("Retrying in %v...\n", waitTime)
-				time.Sleep(waitTime)
-				continue
+				fmt.Fprintf(messageOut, "Retrying in %v...\n", waitTime)
+				select {
+				case <-ctx.Done():
+					lastErr = ctx.Err()
+				case <-time.After(waitTime):
+					continue
+				}
 			}
 			break
 		}
 
 		// Check results
-		successCount := 0
-		existsCount := 0
-		errorCount := 0
+		var timedOut, busy, alreadyExist []kafka.TopicSpecification
+		var busyErr error
 
 		for _, result := range results {
 			if result.Error.Code() == kafka.ErrNoError {
-				fmt.Printf("✅ Successfully created topic '%s'\n", result.Topic)
-				successCount++
+				progress.succeeded(result.Topic, "created",
+					fmt.Sprintf("✅ Successfully created topic '%s'", result.Topic))
+				counts.created = append(counts.created, result.Topic)
+				continue
+			}
+
+			// The request reached a broker that is not (yet) the controller
+			if isControllerBusy(result.Error) && busyRetries < controllerBusyRetries {
+				busy = append(busy, specByName(topicSpecs, result.Topic))
+				busyErr = result.Error
+				continue
+			}
+
+			// The controller did not answer in time for this topic; it may still succeed
+			if result.Error.Code() == kafka.ErrRequestTimedOut && attempt < maxRetries {
+				timedOut = append(timedOut, specByName(topicSpecs, result.Topic))
+				continue
+			}
+
+			// A topic still being deleted is reported as existing, but it is about to vanish
+			if isPendingDeletion(result.Error) {
+				if waitPending {
+					counts.pendingDeletion = append(counts.pendingDeletion, specByName(topicSpecs, result.Topic))
+					continue
+				}
+				progress.failed(result.Topic, result.Error,
+					fmt.Sprintf("❌ Topic '%s' is still being deleted; rerun once the deletion completes or use -wait", result.Topic))
+				failures[result.Topic] = result.Error
 				continue
 			}
 
 			// Topic might already exist, which is not an error for our purposes
 			if result.Error.Code() == kafka.ErrTopicAlreadyExists {
-				fmt.Printf("ℹ️  Topic '%s' already exists\n", result.Topic)
-				existsCount++
+				if tm.opts.StrictCreate {
+					if mismatch := tm.strictCreateMismatch(ctx, specByName(topicSpecs, result.Topic)); mismatch != "" {
+						err := kafka.NewError(kafka.ErrTopicAlreadyExists, mismatch, false)
+						progress.failed(result.Topic, err,
+							fmt.Sprintf("❌ Topic '%s' already exists but differs: %s (-strict-create)", result.Topic, mismatch))
+						failures[result.Topic] = err
+						continue
+					}
+				}
+				alreadyExist = append(alreadyExist, specByName(topicSpecs, result.Topic))
 				continue
 			}
 
 			// Handle other errors
-			fmt.Printf("❌ Failed to create topic '%s': %v\n", result.Topic, result.Error)
-			errorCount++
+			progress.failed(result.Topic, result.Error,
+				fmt.Sprintf("❌ Failed to create topic '%s': %v", result.Topic, result.Error))
+			failures[result.Topic] = result.Error
 		}
+		tm.reportExistingTopics(ctx, alreadyExist, progress)
+		counts.exists += len(alreadyExist)
 
-		// Print summary
-		fmt.Printf("📊 Topic creation summary: %d created, %d already exist, %d errors\n",
-			successCount, existsCount, errorCount)
-
-		// If we have no errors, return success
-		if errorCount == 0 {
-			return nil
+		if len(busy) > 0 {
+			busyRetries++
+			topicSpecs = append(busy, timedOut...)
+			if err := waitControllerBusy(ctx, busyErr, busyRetries); err != nil {
+				lastErr = err
+				break
+			}
+			attempt--
+			continue
+		}
+		if len(timedOut) == 0 {
+			return counts, nil
 		}
 
-		lastErr = fmt.Errorf("some topics failed to create: %d errors out of %d topics",
-			errorCount, topicCount)
+		// A retried topic created by the timed-out request is reported as already existing
+		waitTime := time.Duration(attempt) * 1 * time.Second
+		fmt.Fprintf(messageOut, "⏳ %d topics timed out on the controller, retrying them in %v...\n", len(timedOut), waitTime)
+		topicSpecs = timedOut
+		select {
+		case <-ctx.Done():
+			lastErr = ctx.Err()
+		case <-time.After(waitTime):
+			continue
+		}
 		break
 	}
 
-	return lastErr
+	// The whole request failed without per-topic results, so every remaining topic is a failure
+	for _, spec := range topicSpecs {
+		failures[spec.Topic] = requestError(lastErr)
+		progress.failed(spec.Topic, lastErr, fmt.Sprintf("❌ Failed to create topic '%s': %v", spec.Topic, lastErr))
+	}
+
+	return counts, lastErr
+}
+
+// reportExistingTopics reports the topics a create found already existing, with their
+// current partition count and replication factor from one metadata fetch so a
+// mismatch with the config is visible at a glance
+func (tm *TopicManager) reportExistingTopics(ctx context.Context, topicSpecs []kafka.TopicSpecification, progress *progressReporter) {
+	if len(topicSpecs) == 0 {
+		return
+	}
+
+	// The details only appear in text progress lines
+	var existing map[string]kafka.TopicMetadata
+	if !tm.opts.Quiet && !tm.opts.SummaryOnly && tm.opts.LogFormat != "json" {
+		var err error
+		if existing, err = tm.existingTopicsFor(ctx, topicSpecs); err != nil {
+			fmt.Fprintf(messageOut, "⚠️  Could not fetch metadata of the existing topics: %v\n", err)
+		}
+	}
+
+	for _, spec := range topicSpecs {
+		message := fmt.Sprintf("ℹ️  Topic '%s' already exists", spec.Topic)
+		if metadata, ok := existing[spec.Topic]; ok {
+			if replicationFactor, known := replicationFactorOf(metadata); known {
+				message += fmt.Sprintf(" (%d partitions, replication factor %d)", len(metadata.Partitions), replicationFactor)
+			} else {
+				message += fmt.Sprintf(" (%d partitions)", len(metadata.Partitions))
+			}
+		}
+		progress.succeeded(spec.Topic, "exists", message)
+	}
 }
 
-// increaseTopicPartitions increases the number of partitions for a topic
-func (tm *TopicManager) increaseTopicPartitions(ctx context.Context, topicName string, newPartitionCount int) error {
-	// Create partition specification
-	partitionSpec := []kafka.PartitionsSpecification{
-		{
-			Topic:      topicName,
-			IncreaseTo: newPartitionCount,
-		},
+// createTopicsRequest issues one CreateTopics request, bounded by CreateTimeout when set
+func (tm *TopicManager) createTopicsRequest(ctx context.Context, topicSpecs []kafka.TopicSpecification) ([]kafka.TopicResult, error) {
+	if tm.opts.CreateTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, tm.opts.CreateTimeout)
+		defer cancel()
 	}
 
-	// Alter topic to increase partitions
-	results, err := tm.adminClient.CreatePartitions(ctx, partitionSpec, nil)
+	return tm.adminClient.CreateTopics(ctx, createRequestSpecs(topicSpecs), nil)
+}
+
+// isPendingDeletion reports whether a create failed because an earlier delete of the
+// topic has not completed; brokers report this as TOPIC_ALREADY_EXISTS with a message
+// saying the topic is marked for deletion
+func isPendingDeletion(err kafka.Error) bool {
+	return err.Code() == kafka.ErrTopicAlreadyExists && strings.Contains(strings.ToLower(err.String()), "marked for deletion")
+}
+
+// strictCreateMismatch compares an existing topic with the spec its create requested
+// and describes the first difference in partitions or configs, or returns "" when
+// the topic matches; a topic that cannot be inspected counts as a difference
+func (tm *TopicManager) strictCreateMismatch(ctx context.Context, spec kafka.TopicSpecification) string {
+	metadata, err := tm.getMetadata(ctx, &spec.Topic, false)
 	if err != nil {
-		return fmt.Errorf("failed to increase partitions for topic '%s': %w", topicName, err)
+		return fmt.Sprintf("failed to get metadata: %v", err)
+	}
+	existing, ok := metadata.Topics[spec.Topic]
+	if !ok {
+		return "topic not found in metadata"
+	}
+	if spec.NumPartitions != useBrokerDefault && len(existing.Partitions) != spec.NumPartitions {
+		return fmt.Sprintf("it has %d partitions, %d requested", len(existing.Partitions), spec.NumPartitions)
 	}
 
-	// Check results
-	for _, result := range results {
-		if result.Error.Code() != kafka.ErrNoError {
-			return fmt.Errorf("failed to increase partitions for topic '%s': %v", result.Topic, result.Error)
+	if len(spec.Config) == 0 {
+		return ""
+	}
+	entries, err := tm.getTopicConfigs(ctx, spec.Topic)
+	if err != nil {
+		return err.Error()
+	}
+	names := make([]string, 0, len(spec.Config))
+	for name := range spec.Config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if current := entries[name].Value; current != spec.Config[name] {
+			return fmt.Sprintf("it has %s=%s, %s requested", name, current, spec.Config[name])
 		}
 	}
 
-	return nil
+	return ""
+}
+
+// specByName returns the spec of the named topic from specs
+func specByName(topicSpecs []kafka.TopicSpecification, topic string) kafka.TopicSpecification {
+	for _, spec := range topicSpecs {
+		if spec.Topic == topic {
+			return spec
+		}
+	}
+	return kafka.TopicSpecification{Topic: topic}
+}
+
+// Polling of topics pending deletion under WaitForDeletion
+const (
+	pendingDeletionTimeout      = 2 * time.Minute
+	pendingDeletionPollInterval = time.Second
+)
+
+// waitForDeletion polls the topic's metadata until the broker no longer reports the
+// topic (UNKNOWN_TOPIC_OR_PARTITION), meaning its deletion has completed
+func (tm *TopicManager) waitForDeletion(ctx context.Context, topic string) error {
+	deadline := time.Now().Add(pendingDeletionTimeout)
+	for {
+		metadata, err := tm.getMetadata(ctx, &topic, false)
+		if err != nil {
+			return fmt.Errorf("failed to get metadata for topic '%s': %w", topic, err)
+		}
+		topicMetadata, ok := metadata.Topics[topic]
+		if !ok || topicMetadata.Error.Code() == kafka.ErrUnknownTopicOrPart {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("topic '%s' is still being deleted after %v", topic, pendingDeletionTimeout)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pendingDeletionPollInterval):
+		}
+	}
+}
+
+// requestError converts a request-level error into a kafka.Error for TopicErrors
+func requestError(err error) kafka.Error {
+	var kafkaErr kafka.Error
+	if errors.As(err, &kafkaErr) {
+		return kafkaErr
+	}
+
+	return kafka.NewError(kafka.ErrUnknown, err.Error(), false)
+}
+
+// waitOpDelay pauses for OpDelay, returning early if the context is cancelled
+// (the next admin call then fails with the context error)
+func (tm *TopicManager) waitOpDelay(ctx context.Context) {
+	if tm.opts.OpDelay <= 0 {
+		return
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(tm.opts.OpDelay):
+	}
+}
+
+// Creates rejected because the controller is busy or not elected yet, as while a
+// cluster is starting, are retried up to controllerBusyRetries times, waiting twice as
+// long each time from controllerBusyBaseDelay up to controllerBusyMaxDelay
+const (
+	controllerBusyRetries   = 6
+	controllerBusyBaseDelay = time.Second
+	controllerBusyMaxDelay  = 16 * time.Second
+)
+
+// isControllerBusy reports whether the error means the controller cannot serve the
+// request yet, rather than that the request is wrong or the network is down
+func isControllerBusy(err error) bool {
+	var kafkaErr kafka.Error
+	if !errors.As(err, &kafkaErr) {
+		return false
+	}
+	switch kafkaErr.Code() {
+	case kafka.ErrNotController, kafka.ErrCoordinatorNotAvailable, kafka.ErrCoordinatorLoadInProgress:
+		return true
+	}
+	return false
+}
+
+// waitControllerBusy sleeps for the backoff of the given controller-busy retry, or
+// returns the context error when the context ends first
+func waitControllerBusy(ctx context.Context, cause error, retry int) error {
+	delay := min(controllerBusyBaseDelay<<(retry-1), controllerBusyMaxDelay)
+	fmt.Fprintf(messageOut, "⏳ Controller busy (%v), retrying in %v (%d/%d)...\n", cause, delay, retry, controllerBusyRetries)
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return nil
+	}
+}
+
+// chunkTopicSpecs splits specs into consecutive batches of at most size entries
+// (a size of 0 or less keeps everything in a single batch)
+func chunkTopicSpecs(topicSpecs []kafka.TopicSpecification, size int) [][]kafka.TopicSpecification {
+	if size <= 0 || len(topicSpecs) <= size {
+		return [][]kafka.TopicSpecification{topicSpecs}
+	}
+
+	var batches [][]kafka.TopicSpecification
+	for start := 0; start < len(topicSpecs); start += size {
+		end := min(start+size, len(topicSpecs))
+		batches = append(batches, topicSpecs[start:end])
+	}
+
+	return batches
 }
 
 // isRetryableError determines if an error should trigger a retry
@@ -269,6 +1456,19 @@ func isRetryableError(err error) bool {
 		return false
 	}
 
+	// Transient client and broker conditions, e.g. right after a broker restart
+	var kafkaErr kafka.Error
+	if errors.As(err, &kafkaErr) {
+		switch kafkaErr.Code() {
+		case kafka.ErrTransport, kafka.ErrTimedOut, kafka.ErrAllBrokersDown, kafka.ErrLeaderNotAvailable,
+			kafka.ErrNotController, kafka.ErrRequestTimedOut:
+			return true
+		}
+		if kafkaErr.IsRetriable() {
+			return true
+		}
+	}
+
 	errStr := err.Error()
 	// Retry on connection-related errors
 	retryableErrors := []string{
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// syncDecisionCases pairs the topic already on the FakeAdmin (none when existing is
+// nil) with the desired spec and the decision sync is expected to make
+var syncDecisionCases = []struct {
+	name     string
+	existing *kafka.TopicSpecification
+	desired  kafka.TopicSpecification
+	want     string // create, update, unchanged or cannot-scale-down
+}{
+	{
+		name:    "missing topic is created",
+		desired: kafka.TopicSpecification{Topic: "orders", NumPartitions: 3, ReplicationFactor: 1},
+		want:    "create",
+	},
+	{
+		name:     "more partitions is an update",
+		existing: &kafka.TopicSpecification{Topic: "orders", NumPartitions: 3, ReplicationFactor: 1},
+		desired:  kafka.TopicSpecification{Topic: "orders", NumPartitions: 6, ReplicationFactor: 1},
+		want:     "update",
+	},
+	{
+		name: "config drift is an update",
+		existing: &kafka.TopicSpecification{Topic: "orders", NumPartitions: 3, ReplicationFactor: 1,
+			Config: map[string]string{"retention.ms": "86400000"}},
+		desired: kafka.TopicSpecification{Topic: "orders", NumPartitions: 3, ReplicationFactor: 1,
+			Config: map[string]string{"retention.ms": "604800000"}},
+		want: "update",
+	},
+	{
+		name: "equal topic is unchanged",
+		existing: &kafka.TopicSpecification{Topic: "orders", NumPartitions: 3, ReplicationFactor: 1,
+			Config: map[string]string{"retention.ms": "86400000"}},
+		desired: kafka.TopicSpecification{Topic: "orders", NumPartitions: 3, ReplicationFactor: 1,
+			Config: map[string]string{"retention.ms": "86400000"}},
+		want: "unchanged",
+	},
+	{
+		name:     "fewer partitions cannot scale down",
+		existing: &kafka.TopicSpecification{Topic: "orders", NumPartitions: 6, ReplicationFactor: 1},
+		desired:  kafka.TopicSpecification{Topic: "orders", NumPartitions: 3, ReplicationFactor: 1},
+		want:     "cannot-scale-down",
+	},
+}
+
+// newTestManager returns a TopicManager on a single-broker FakeAdmin holding the topic
+func newTestManager(t *testing.T, existing *kafka.TopicSpecification) *TopicManager {
+	t.Helper()
+
+	fake := NewFakeAdmin(1)
+	if existing != nil {
+		results, err := fake.CreateTopics(context.Background(), []kafka.TopicSpecification{*existing})
+		if err != nil || results[0].Error.Code() != kafka.ErrNoError {
+			t.Fatalf("failed to create topic '%s' on the FakeAdmin: %v %v", existing.Topic, err, results)
+		}
+	}
+
+	return NewTopicManager(fake, ManagerOptions{
+		Quiet:      true,
+		LogFormat:  "text",
+		Output:     "text",
+		ConfigMode: configModeIncremental,
+	})
+}
+
+func TestPlanSyncDecisions(t *testing.T) {
+	for _, tc := range syncDecisionCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tm := newTestManager(t, tc.existing)
+
+			plan, err := tm.PlanSync(context.Background(), []kafka.TopicSpecification{tc.desired})
+			if err != nil {
+				t.Fatalf("PlanSync failed: %v", err)
+			}
+
+			got := map[string]int{
+				"create":            len(plan.ToCreate),
+				"update":            len(plan.ToUpdate),
+				"unchanged":         len(plan.Unchanged),
+				"cannot-scale-down": len(plan.CannotScaleDown),
+			}
+			for decision, count := range got {
+				want := 0
+				if decision == tc.want {
+					want = 1
+				}
+				if count != want {
+					t.Errorf("plan has %d topics to %s, want %d", count, decision, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSyncTopicsDecisions(t *testing.T) {
+	for _, tc := range syncDecisionCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tm := newTestManager(t, tc.existing)
+
+			result, err := tm.SyncTopics(context.Background(), []kafka.TopicSpecification{tc.desired})
+			if err != nil {
+				t.Fatalf("SyncTopics failed: %v", err)
+			}
+
+			got := map[string]int{
+				"create":            result.Created,
+				"update":            result.Updated,
+				"unchanged":         result.Unchanged,
+				"cannot-scale-down": result.CannotScaleDown,
+			}
+			for decision, count := range got {
+				want := 0
+				if decision == tc.want {
+					want = 1
+				}
+				if count != want {
+					t.Errorf("sync counted %d topics as %s, want %d", count, decision, want)
+				}
+			}
+			if result.Failed != 0 {
+				t.Errorf("sync counted %d failed topics, want 0", result.Failed)
+			}
+
+			// Applied changes must leave the FakeAdmin matching the desired spec
+			if tc.want == "create" || tc.want == "update" {
+				plan, err := tm.PlanSync(context.Background(), []kafka.TopicSpecification{tc.desired})
+				if err != nil {
+					t.Fatalf("PlanSync after sync failed: %v", err)
+				}
+				if len(plan.Unchanged) != 1 {
+					t.Errorf("topic still differs after sync: %d to create, %d to update",
+						len(plan.ToCreate), len(plan.ToUpdate))
+				}
+			}
+		})
+	}
+}
+
+func TestSyncTopicsRebalance(t *testing.T) {
+	fake := NewFakeAdmin(3)
+	specs := []kafka.TopicSpecification{
+		{Topic: "orders", NumPartitions: 2, ReplicationFactor: 3},
+		{Topic: "payments", NumPartitions: 1, ReplicationFactor: 3},
+	}
+	if _, err := fake.CreateTopics(context.Background(), specs); err != nil {
+		t.Fatalf("failed to create topics on the FakeAdmin: %v", err)
+	}
+	for _, name := range []string{"orders", "payments"} {
+		if err := fake.MoveLeader(name, 0); err != nil {
+			t.Fatalf("failed to move the leader of '%s': %v", name, err)
+		}
+	}
+
+	tm := NewTopicManager(fake, ManagerOptions{
+		Quiet:      true,
+		LogFormat:  "text",
+		Output:     "text",
+		ConfigMode: configModeIncremental,
+		Protected:  []string{"payments"},
+		Rebalance:  true,
+	})
+	result, err := tm.SyncTopics(context.Background(), specs)
+	if err != nil {
+		t.Fatalf("SyncTopics failed: %v", err)
+	}
+	if result.LeadersElected != 1 || result.LeadersFailed != 0 {
+		t.Errorf("sync elected %d leaders and failed %d, want 1 and 0", result.LeadersElected, result.LeadersFailed)
+	}
+
+	existing, err := tm.GetExistingTopics(context.Background())
+	if err != nil {
+		t.Fatalf("GetExistingTopics failed: %v", err)
+	}
+	if imbalanced := tm.leaderImbalance(existing, specs[:1], nil); len(imbalanced) != 0 {
+		t.Errorf("'orders' still has %d partitions off their preferred leader", len(imbalanced))
+	}
+	if leader := existing["payments"].Partitions[0].Leader; leader == existing["payments"].Partitions[0].Replicas[0] {
+		t.Errorf("protected topic 'payments' had its leader moved back to broker %d", leader)
+	}
+}
+
+// racingAdmin is a FakeAdmin on which another client creates a topic right before
+// this one sends its CreateTopics request
+type racingAdmin struct {
+	*FakeAdmin
+	raced kafka.TopicSpecification
+}
+
+func (r *racingAdmin) CreateTopics(ctx context.Context, topics []kafka.TopicSpecification,
+	options ...kafka.CreateTopicsAdminOption) ([]kafka.TopicResult, error) {
+	if _, err := r.FakeAdmin.CreateTopics(ctx, []kafka.TopicSpecification{r.raced}); err != nil {
+		return nil, err
+	}
+	return r.FakeAdmin.CreateTopics(ctx, topics, options...)
+}
+
+func TestSyncTopicsConcurrentCreate(t *testing.T) {
+	specs := []kafka.TopicSpecification{
+		{Topic: "orders", NumPartitions: 3, ReplicationFactor: 1},
+		{Topic: "payments", NumPartitions: 3, ReplicationFactor: 1},
+	}
+	admin := &racingAdmin{FakeAdmin: NewFakeAdmin(1), raced: specs[1]}
+	tm := NewTopicManager(admin, ManagerOptions{
+		Quiet:      true,
+		LogFormat:  "text",
+		Output:     "text",
+		ConfigMode: configModeIncremental,
+	})
+
+	result, err := tm.SyncTopics(context.Background(), specs)
+	if err != nil {
+		t.Fatalf("SyncTopics failed: %v", err)
+	}
+	if result.Created != 1 || result.Unchanged != 1 || result.Failed != 0 {
+		t.Errorf("sync counted %d created, %d unchanged and %d failed, want 1, 1 and 0",
+			result.Created, result.Unchanged, result.Failed)
+	}
+	if len(result.CreatedTopics) != 1 || result.CreatedTopics[0] != "orders" {
+		t.Errorf("sync reported %v as created, want [orders]", result.CreatedTopics)
+	}
+}
+
+func TestNothingToDoDetail(t *testing.T) {
+	cases := []struct {
+		name   string
+		result SyncResult
+		want   string
+	}{
+		{"every topic matches", SyncResult{Unchanged: 12}, "all 12 topics already match the configuration"},
+		{"create-only", SyncResult{Skipped: 4}, "all 4 topics already exist (-create-only)"},
+		{"some topics protected", SyncResult{Unchanged: 2, Protected: 1}, "2 topics already match the configuration"},
+		{"some creates refused", SyncResult{Unchanged: 2, SkippedCreates: 3}, "2 topics already match the configuration"},
+		{"every topic protected", SyncResult{Protected: 3}, "no topic needs to be created or updated"},
+		{"every create refused", SyncResult{SkippedCreates: 2}, "no topic needs to be created or updated"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := nothingToDoDetail(tc.result); got != tc.want {
+				t.Errorf("nothingToDoDetail() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
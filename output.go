@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+)
+
+// Values of -color, which decides whether output keeps its emoji decorations
+const (
+	colorAuto   = "auto"   // Decorated on a terminal unless NO_COLOR is set
+	colorAlways = "always" // Decorated even when piped to a file
+	colorNever  = "never"  // Plain text
+)
+
+// decorationPattern matches the emoji (with variation selectors and trailing spaces)
+// that open an output line, after any indentation, log timestamp or "[42/300] "
+// progress prefix. Emoji inside a line, e.g. in a JSON description, are left alone.
+var decorationPattern = regexp.MustCompile(
+	`(?m)^([ \t]*(?:\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2} )?(?:\[\d+/\d+\] )?)[\x{2139}\x{231A}-\x{23FF}\x{2600}-\x{27BF}\x{2B00}-\x{2BFF}\x{1F300}-\x{1FAFF}\x{FE0F}\x{200D}]+ *`)
+
+// plainWriter strips line-opening decorations from the text written through it
+type plainWriter struct {
+	w         io.Writer
+	lineStart bool
+}
+
+func (p *plainWriter) Write(data []byte) (int, error) {
+	out := data
+	if p.lineStart {
+		out = decorationPattern.ReplaceAll(data, []byte("$1"))
+	} else if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		// The first line continues one started by an earlier write
+		out = append(data[:i+1:i+1], decorationPattern.ReplaceAll(data[i+1:], []byte("$1"))...)
+	}
+	if len(data) > 0 {
+		p.lineStart = data[len(data)-1] == '\n'
+	}
+
+	if _, err := p.w.Write(out); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// plainOutput is a stream piped through a plainWriter; done is closed once the pipe
+// is drained
+type plainOutput struct {
+	writer *os.File
+	done   chan struct{}
+}
+
+// plainOutputs holds the streams set up by setupOutput
+var plainOutputs []plainOutput
+
+// messageOut receives the informational, progress and warning lines of a command.
+// Modes that keep stdout for data, such as -output jsonl or -dump-specs, point it at
+// stderr, and -summary-only discards it; the data itself is written to os.Stdout.
+var messageOut io.Writer = os.Stdout
+
+// summaryOut receives the final summary lines and confirmation prompts, the only
+// output left on stdout under -summary-only
+var summaryOut io.Writer = os.Stdout
+
+// resultOut receives the -result-line footer. It stays on stdout when -output jsonl
+// or -summary-only move or discard everything else printed there.
+var resultOut io.Writer = os.Stdout
+
+// useDecorations reports whether -color and NO_COLOR leave the emoji decorations in
+// the lines written to stream
+func useDecorations(mode string, stream *os.File) (bool, error) {
+	switch mode {
+	case colorAlways:
+		return true, nil
+	case colorNever:
+		return false, nil
+	case colorAuto:
+		return os.Getenv("NO_COLOR") == "" && isTerminal(stream), nil
+	}
+	return false, fmt.Errorf("unknown -color '%s' (expected auto, always or never)", mode)
+}
+
+// setupOutput applies -color to stdout and stderr separately: a stream that gets plain
+// output is replaced by a pipe whose content is copied to it without decorations
+func setupOutput(mode string) error {
+	stdoutDecorated, err := useDecorations(mode, os.Stdout)
+	if err != nil {
+		return err
+	}
+	stderrDecorated, err := useDecorations(mode, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	if !stdoutDecorated {
+		stdout, err := plainStream(os.Stdout)
+		if err != nil {
+			return err
+		}
+		os.Stdout = stdout
+
+		// Writers captured before the swap
+		messageOut, statusOut, operationsOut, summaryOut, resultOut = os.Stdout, os.Stdout, os.Stdout, os.Stdout, os.Stdout
+	}
+	if !stderrDecorated {
+		stderr, err := plainStream(os.Stderr)
+		if err != nil {
+			return err
+		}
+		os.Stderr = stderr
+		log.SetOutput(os.Stderr)
+	}
+	return nil
+}
+
+// plainStream returns the write end of a pipe copied to target through a plainWriter
+func plainStream(target *os.File) (*os.File, error) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up plain output: %w", err)
+	}
+
+	done := make(chan struct{})
+	plainOutputs = append(plainOutputs, plainOutput{writer: writer, done: done})
+	go func() {
+		defer close(done)
+		io.Copy(&plainWriter{w: target, lineStart: true}, reader)
+	}()
+
+	return writer, nil
+}
+
+// flushOutput closes the plain output pipes and waits until everything written to
+// them has reached the real streams
+func flushOutput() {
+	for _, output := range plainOutputs {
+		output.writer.Close()
+	}
+	for _, output := range plainOutputs {
+		<-output.done
+	}
+	plainOutputs = nil
+}
+
+// showSummaryOnly applies -summary-only: summaryOut is kept, while every other line,
+// including connection diagnostics, is discarded. Errors logged to stderr are still
+// shown.
+func showSummaryOnly() {
+	messageOut = io.Discard
+	statusOut = io.Discard
+}
+
+// exit flushes the output and ends the process with the exit code; a failing run
+// that still owes its -result-line footer prints it with zero counts first
+func exit(code int) {
+	if resultLinePending && code != exitOK {
+		printResultLine(SyncResult{})
+	}
+	flushOutput()
+	os.Exit(code)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPlainWriter(t *testing.T) {
+	cases := []struct {
+		name   string
+		writes []string
+		want   string
+	}{
+		{
+			name:   "decorated lines",
+			writes: []string{"✅ Created topic 'orders'\n⚠️  Topic 'payments' is protected\n"},
+			want:   "Created topic 'orders'\nTopic 'payments' is protected\n",
+		},
+		{
+			name:   "progress and log prefixes",
+			writes: []string{"[1/3] ✅ Created topic 'orders'\n2026/10/14 06:51:05 ❌ Failed to connect\n"},
+			want:   "[1/3] Created topic 'orders'\n2026/10/14 06:51:05 Failed to connect\n",
+		},
+		{
+			name:   "emoji inside a line",
+			writes: []string{"description: orders 📦\n"},
+			want:   "description: orders 📦\n",
+		},
+		{
+			name:   "write splitting a line",
+			writes: []string{"✅ Created topic ", "'orders' ✅\n", "📋 Syncing 3 topics\n"},
+			want:   "Created topic 'orders' ✅\nSyncing 3 topics\n",
+		},
+		{
+			name:   "write ending a line and starting the next",
+			writes: []string{"🔄 Updating ", "'orders'\n⚠️  Topic", " 'payments' ✅ skipped\n"},
+			want:   "Updating 'orders'\nTopic 'payments' ✅ skipped\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			writer := &plainWriter{w: &out, lineStart: true}
+			for _, data := range tc.writes {
+				n, err := writer.Write([]byte(data))
+				if err != nil || n != len(data) {
+					t.Fatalf("Write(%q) = %d, %v, want %d, nil", data, n, err, len(data))
+				}
+			}
+			if out.String() != tc.want {
+				t.Errorf("plainWriter wrote %q, want %q", out.String(), tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// guardPartitionIncreases warns about every planned partition increase, since it
+// changes the key-to-partition mapping, and drops the increase of topics that look
+// keyed (compacted) unless AllowPartitionIncrease is set. Updates left without any
+// change are removed from the plan.
+func (tm *TopicManager) guardPartitionIncreases(ctx context.Context, plan *SyncPlan) error {
+	var names []string
+	for _, update := range plan.ToUpdate {
+		if update.needsPartitionIncrease {
+			names = append(names, update.topic)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	policies, err := tm.cleanupPolicies(ctx, names)
+	if err != nil {
+		return err
+	}
+
+	updates := plan.ToUpdate[:0]
+	for _, update := range plan.ToUpdate {
+		if !update.needsPartitionIncrease {
+			updates = append(updates, update)
+			continue
+		}
+
+		policy := policies[update.topic]
+		if desired, ok := update.desired.Config["cleanup.policy"]; ok {
+			policy = desired
+		}
+		from, to := len(update.current.Partitions), update.desired.NumPartitions
+
+		if !strings.Contains(policy, "compact") || tm.opts.AllowPartitionIncrease {
+			fmt.Fprintf(messageOut, "⚠️  Increasing partitions of topic '%s' from %d to %d changes which partition each key maps to; "+
+				"consumers relying on per-key ordering may see keys move\n", update.topic, from, to)
+			updates = append(updates, update)
+			continue
+		}
+
+		fmt.Fprintf(messageOut, "⚠️  Not increasing partitions of compacted topic '%s' from %d to %d: keys would move to other partitions "+
+			"(use -allow-partition-increase to proceed)\n", update.topic, from, to)
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("partition increase of compacted topic '%s' skipped", update.topic))
+		update.needsPartitionIncrease = false
+		if len(update.configChanges) > 0 {
+			updates = append(updates, update)
+		}
+	}
+	plan.ToUpdate = updates
+
+	return nil
+}
+
+// cleanupPolicies returns the current cleanup.policy of the named topics
+func (tm *TopicManager) cleanupPolicies(ctx context.Context, names []string) (map[string]string, error) {
+	configs, err := tm.describeTopicConfigs(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make(map[string]string, len(configs))
+	for topic, entries := range configs {
+		policies[topic] = entries["cleanup.policy"].Value
+	}
+
+	return policies, nil
+}
+
+// increasePartitions increases the partitions of every update that needs it with a
+// single CreatePartitions request, returning the per-topic failures and the number of
+// topics increased. A request that fails as a whole fails each topic in it.
+func (tm *TopicManager) increasePartitions(ctx context.Context, updates []topicUpdateInfo) (TopicErrors, int) {
+	var specs []kafka.PartitionsSpecification
+	for _, update := range updates {
+		if !update.needsPartitionIncrease {
+			continue
+		}
+		spec := kafka.PartitionsSpecification{Topic: update.topic, IncreaseTo: update.desired.NumPartitions}
+		// With a manual assignment, the new partitions are placed as it lists them
+		if update.desired.ReplicaAssignment != nil {
+			spec.ReplicaAssignment = update.desired.ReplicaAssignment[len(update.current.Partitions):]
+		}
+		specs = append(specs, spec)
+	}
+	if len(specs) == 0 {
+		return nil, 0
+	}
+
+	failures := make(TopicErrors)
+	results, err := tm.adminClient.CreatePartitions(ctx, specs, nil)
+	if err != nil {
+		for _, spec := range specs {
+			failures[spec.Topic] = requestError(err)
+		}
+		return failures, 0
+	}
+
+	for _, result := range results {
+		if result.Error.Code() != kafka.ErrNoError {
+			failures[result.Topic] = result.Error
+		}
+	}
+	if len(specs) > 1 {
+		fmt.Fprintf(messageOut, "📊 Partition increases: %d succeeded, %d failed\n", len(specs)-len(failures), len(failures))
+	}
+
+	return failures, len(specs) - len(failures)
+}
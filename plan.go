@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+)
+
+// printSyncPlan prints the changes a sync would make, one line per affected topic
+func printSyncPlan(plan SyncPlan) {
+	fmt.Fprintf(messageOut, "📝 Plan: %d to create, %d to update, %d unchanged, %d cannot scale down\n",
+		len(plan.ToCreate), len(plan.ToUpdate), len(plan.Unchanged), len(plan.CannotScaleDown))
+	if len(plan.Skipped) > 0 {
+		fmt.Fprintf(messageOut, "⏭️  %d existing topics skipped (-create-only)\n", len(plan.Skipped))
+	}
+	if len(plan.Protected) > 0 {
+		fmt.Fprintf(messageOut, "🛡️  %d protected topics left untouched\n", len(plan.Protected))
+	}
+	if len(plan.SkippedCreates) > 0 {
+		fmt.Fprintf(messageOut, "⏭️  %d missing topics would not be created (-update-only)\n", len(plan.SkippedCreates))
+	}
+
+	for _, spec := range plan.ToCreate {
+		fmt.Fprintf(messageOut, "   + %-40s Partitions: %s Replication: %s\n",
+			spec.Topic, countLabel(spec.NumPartitions), countLabel(spec.ReplicationFactor))
+	}
+	for _, update := range plan.ToUpdate {
+		if update.needsPartitionIncrease {
+			fmt.Fprintf(messageOut, "   ~ %-40s Partitions: %d → %d\n",
+				update.topic, len(update.current.Partitions), update.desired.NumPartitions)
+		}
+		for _, change := range update.configChanges {
+			if change.reset {
+				fmt.Fprintf(messageOut, "   ~ %-40s %s: %s → (default)\n", update.topic, change.name, change.current)
+			} else {
+				current := change.current
+				if current == "" {
+					current = "(unset)"
+				}
+				fmt.Fprintf(messageOut, "   ~ %-40s %s: %s → %s\n", update.topic, change.name, current, change.desired)
+			}
+		}
+	}
+	for _, info := range plan.CannotScaleDown {
+		fmt.Fprintf(messageOut, "   ! %-40s Partitions: %d → %d (cannot scale down)\n",
+			info.topic, info.currentPartitions, info.desiredPartitions)
+	}
+
+	if len(plan.ToCreate) == 0 && len(plan.ToUpdate) == 0 {
+		fmt.Fprintln(messageOut, "✅ No changes: the cluster matches the configuration")
+	}
+}
+
+// hasChanges reports whether the cluster differs from the configuration, including
+// partition decreases that sync cannot apply
+func (p SyncPlan) hasChanges() bool {
+	return len(p.ToCreate) > 0 || len(p.ToUpdate) > 0 || len(p.CannotScaleDown) > 0
+}
+
+// countLabel formats a partition or replication count, naming the sentinel values
+func countLabel(n int) string {
+	switch n {
+	case useBrokerDefault:
+		return "broker default"
+	case autoReplicationFactor:
+		return "auto"
+	}
+	return fmt.Sprintf("%d", n)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigPolicy is a governance file maintained by the platform team, listing the topic
+// config keys application teams may set. An environment entry replaces the shared list
+// for that environment.
+type ConfigPolicy struct {
+	AllowedConfigKeys []string                     `yaml:"allowed_config_keys"`
+	Environments      map[string]ConfigPolicyScope `yaml:"environments"`
+}
+
+// ConfigPolicyScope is the allow-list of a single environment
+type ConfigPolicyScope struct {
+	AllowedConfigKeys []string `yaml:"allowed_config_keys"`
+}
+
+// defaultPolicyFile returns KAFKA_POLICY_FILE, used when -policy is not given
+func defaultPolicyFile() string {
+	// Load the .env file if it exists so KAFKA_POLICY_FILE can be set there too
+	loadEnvFile()
+
+	return os.Getenv("KAFKA_POLICY_FILE")
+}
+
+// readConfigPolicy reads and strictly parses a policy file from a path or HTTP(S) URL
+func readConfigPolicy(policyFile string) (ConfigPolicy, error) {
+	var policy ConfigPolicy
+
+	data, err := readConfigSource(policyFile)
+	if err != nil {
+		return policy, err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&policy); err != nil && !errors.Is(err, io.EOF) {
+		return policy, fmt.Errorf("failed to parse policy file %s: %w", policyFile, err)
+	}
+
+	return policy, nil
+}
+
+// allowedKeys returns the config keys the policy permits in the environment, or nil
+// when neither the environment nor the shared list restricts them
+func (p ConfigPolicy) allowedKeys(environment string) map[string]bool {
+	keys := p.AllowedConfigKeys
+	if scope, ok := p.Environments[environment]; ok {
+		keys = scope.AllowedConfigKeys
+	}
+	if keys == nil {
+		return nil
+	}
+
+	allowed := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		allowed[key] = true
+	}
+	return allowed
+}
+
+// checkConfigPolicy returns an error naming the topic and its first config key, in
+// sorted order, that the allow-list does not contain
+func checkConfigPolicy(topic string, config map[string]string, allowed map[string]bool, policyFile string) error {
+	if allowed == nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !allowed[key] {
+			return fmt.Errorf("topic '%s' sets config key '%s', which the policy file %s does not allow", topic, key, policyFile)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// checkMinBrokers fails when the cluster reports fewer live brokers than MinBrokers,
+// so topics are not created under-replicated during a rolling restart
+func (tm *TopicManager) checkMinBrokers(ctx context.Context) error {
+	if tm.opts.MinBrokers <= 0 {
+		return nil
+	}
+
+	metadata, err := tm.getMetadata(ctx, nil, false)
+	if err != nil {
+		return &ConnectionError{Err: fmt.Errorf("failed to get metadata: %w", err)}
+	}
+	if len(metadata.Brokers) < tm.opts.MinBrokers {
+		return &ConnectionError{Err: fmt.Errorf("only %d of the required %d brokers are available (-min-brokers); the cluster may still be starting or restarting",
+			len(metadata.Brokers), tm.opts.MinBrokers)}
+	}
+
+	if !tm.opts.Quiet {
+		fmt.Fprintf(messageOut, "🖥️  %d brokers available (at least %d required)\n", len(metadata.Brokers), tm.opts.MinBrokers)
+	}
+	return nil
+}
+
+// checkMessageSizeLimits compares each topic's requested max.message.bytes with the
+// broker's message.max.bytes and returns a warning for every topic that exceeds it
+func (tm *TopicManager) checkMessageSizeLimits(ctx context.Context, topicSpecs []kafka.TopicSpecification) ([]string, error) {
+	var limited []kafka.TopicSpecification
+	for _, spec := range topicSpecs {
+		if _, ok := spec.Config["max.message.bytes"]; ok {
+			limited = append(limited, spec)
+		}
+	}
+	if len(limited) == 0 {
+		return nil, nil
+	}
+
+	brokerLimit, err := tm.brokerMessageMaxBytes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	for _, spec := range limited {
+		requested, err := strconv.ParseInt(spec.Config["max.message.bytes"], 10, 64)
+		if err != nil {
+			continue // Rejected by validateTopicConfig already
+		}
+		if requested > brokerLimit {
+			fmt.Fprintf(messageOut, "⚠️  Topic '%s' max.message.bytes %d exceeds broker message.max.bytes %d\n",
+				spec.Topic, requested, brokerLimit)
+			warnings = append(warnings, fmt.Sprintf("topic '%s' max.message.bytes %d exceeds broker message.max.bytes %d",
+				spec.Topic, requested, brokerLimit))
+		}
+	}
+
+	return warnings, nil
+}
+
+// brokerMessageMaxBytes reads message.max.bytes from the first broker in the metadata
+func (tm *TopicManager) brokerMessageMaxBytes(ctx context.Context) (int64, error) {
+	metadata, err := tm.getMetadata(ctx, nil, false)
+	if err != nil {
+		return 0, &ConnectionError{Err: fmt.Errorf("failed to get metadata: %w", err)}
+	}
+	if len(metadata.Brokers) == 0 {
+		return 0, fmt.Errorf("no brokers returned in metadata")
+	}
+
+	brokerID := strconv.Itoa(int(metadata.Brokers[0].ID))
+	results, err := tm.adminClient.DescribeConfigs(ctx, []kafka.ConfigResource{
+		{Type: kafka.ResourceBroker, Name: brokerID},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to describe configs for broker %s: %w", brokerID, err)
+	}
+
+	for _, result := range results {
+		if result.Error.Code() != kafka.ErrNoError {
+			return 0, fmt.Errorf("failed to describe configs for broker %s: %v", brokerID, result.Error)
+		}
+		if entry, ok := result.Config["message.max.bytes"]; ok {
+			value, err := strconv.ParseInt(entry.Value, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("broker %s reported invalid message.max.bytes '%s'", brokerID, entry.Value)
+			}
+			return value, nil
+		}
+	}
+
+	return 0, fmt.Errorf("broker %s did not report message.max.bytes", brokerID)
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// operationsOut receives the -output jsonl stream; streamOperations points it at the
+// real stdout
+var operationsOut io.Writer = os.Stdout
+
+// progressReporter prints one line per completed topic operation, prefixed with the
+// running count (e.g. "[42/300] ✅ Successfully created topic 'orders.events'").
+// Under Quiet only failures are printed; with the json log format each completion
+// is emitted as a structured event instead.
+type progressReporter struct {
+	action string // e.g. create or update
+	total  int
+	done   int
+	opts   ManagerOptions
+}
+
+// progressEvent is the structured form of a progress line
+type progressEvent struct {
+	Event   string `json:"event"`
+	Action  string `json:"action"`
+	Topic   string `json:"topic"`
+	Status  string `json:"status"`
+	Current int    `json:"current"`
+	Total   int    `json:"total"`
+	Error   string `json:"error,omitempty"`
+}
+
+// operationEvent is one line of the -output jsonl stream, written as each operation
+// completes; status is ok or failed and detail the outcome, e.g. created or exists
+type operationEvent struct {
+	Time   string `json:"time"`
+	Topic  string `json:"topic"`
+	Action string `json:"action"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// streamOperations reserves stdout for the -output jsonl stream by sending everything
+// else, including connection diagnostics and summaries, to stderr
+func streamOperations() {
+	messageOut = os.Stderr
+	statusOut = os.Stderr
+	summaryOut = os.Stderr
+}
+
+func newProgressReporter(action string, total int, opts ManagerOptions) *progressReporter {
+	return &progressReporter{action: action, total: total, opts: opts}
+}
+
+// succeeded records a completed operation; status is e.g. created or exists
+func (p *progressReporter) succeeded(topic, status, message string) {
+	p.done++
+	p.stream(operationEvent{Topic: topic, Status: "ok", Detail: status})
+	if p.opts.LogFormat == "json" {
+		p.emit(progressEvent{Topic: topic, Status: status})
+		return
+	}
+	if !p.opts.Quiet {
+		fmt.Fprintf(messageOut, "[%d/%d] %s\n", p.done, p.total, message)
+	}
+}
+
+// failed records a failed operation, which is printed even under Quiet
+func (p *progressReporter) failed(topic string, err error, message string) {
+	p.done++
+	p.stream(operationEvent{Topic: topic, Status: "failed", Error: err.Error()})
+	if p.opts.LogFormat == "json" {
+		p.emit(progressEvent{Topic: topic, Status: "failed", Error: err.Error()})
+		return
+	}
+	fmt.Fprintf(messageOut, "[%d/%d] %s\n", p.done, p.total, message)
+}
+
+func (p *progressReporter) emit(event progressEvent) {
+	event.Event = "progress"
+	event.Action = p.action
+	event.Current = p.done
+	event.Total = p.total
+	if err := json.NewEncoder(messageOut).Encode(event); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to encode progress event: %v\n", err)
+	}
+}
+
+// stream writes the operation to the -output jsonl stream, if enabled
+func (p *progressReporter) stream(event operationEvent) {
+	if p.opts.Output != "jsonl" {
+		return
+	}
+	event.Time = time.Now().UTC().Format(time.RFC3339Nano)
+	event.Action = p.action
+	if err := json.NewEncoder(operationsOut).Encode(event); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to encode operation event: %v\n", err)
+	}
+}
+
+// summaryEvent is the structured form of the sync summary, emitted after the
+// progress events with the json log format
+type summaryEvent struct {
+	Event           string         `json:"event"`
+	Created         int            `json:"created"`
+	Updated         int            `json:"updated"`
+	Unchanged       int            `json:"unchanged"`
+	CannotScaleDown int            `json:"cannot_scale_down"`
+	Failed          int            `json:"failed"`
+	TimingsMs       map[string]int `json:"timings_ms"`
+}
+
+// emitSummaryEvent writes the sync result and its phase timings as a summary event
+func emitSummaryEvent(result SyncResult) {
+	event := summaryEvent{
+		Event:           "summary",
+		Created:         result.Created,
+		Updated:         result.Updated,
+		Unchanged:       result.Unchanged,
+		CannotScaleDown: result.CannotScaleDown,
+		Failed:          result.Failed,
+		TimingsMs: map[string]int{
+			"metadata":           int(result.Timings.Metadata / time.Millisecond),
+			"create":             int(result.Timings.Create / time.Millisecond),
+			"partition_increase": int(result.Timings.PartitionIncrease / time.Millisecond),
+			"config_alter":       int(result.Timings.ConfigAlter / time.Millisecond),
+		},
+	}
+	if err := json.NewEncoder(summaryOut).Encode(event); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to encode summary event: %v\n", err)
+	}
+}
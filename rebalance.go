@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// partitionLeaderElector is implemented by admins that elect leaders of a partition
+// list directly, such as FakeAdmin, which cannot see inside a kafka.ElectLeadersRequest
+type partitionLeaderElector interface {
+	ElectPartitionLeaders(ctx context.Context, electionType kafka.ElectionType,
+		partitions []kafka.TopicPartition) (kafka.ElectLeadersResult, error)
+}
+
+// electPreferredLeaders runs a preferred leader election for the partitions, handing
+// them to the admin directly when it accepts a partition list
+func (tm *TopicManager) electPreferredLeaders(ctx context.Context, partitions []kafka.TopicPartition) (kafka.ElectLeadersResult, error) {
+	if elector, ok := tm.adminClient.(partitionLeaderElector); ok {
+		return elector.ElectPartitionLeaders(ctx, kafka.ElectionTypePreferred, partitions)
+	}
+	return tm.adminClient.ElectLeaders(ctx, kafka.NewElectLeadersRequest(kafka.ElectionTypePreferred, partitions))
+}
+
+// leaderImbalance returns the partitions of the given topics whose leader is not their
+// preferred replica, the first one listed, sorted by topic and partition. Protected
+// topics and the topics in skipped are left out.
+func (tm *TopicManager) leaderImbalance(existingTopics map[string]kafka.TopicMetadata,
+	topicSpecs []kafka.TopicSpecification, skipped []string) []kafka.TopicPartition {
+	var partitions []kafka.TopicPartition
+	for _, spec := range topicSpecs {
+		if tm.isProtected(spec.Topic) || slices.Contains(skipped, spec.Topic) {
+			continue
+		}
+		metadata, exists := existingTopics[spec.Topic]
+		if !exists {
+			continue
+		}
+		for _, partition := range metadata.Partitions {
+			if len(partition.Replicas) > 0 && partition.Leader != partition.Replicas[0] {
+				topic := spec.Topic
+				partitions = append(partitions, kafka.TopicPartition{Topic: &topic, Partition: partition.ID})
+			}
+		}
+	}
+
+	sort.Slice(partitions, func(i, j int) bool {
+		if *partitions[i].Topic != *partitions[j].Topic {
+			return *partitions[i].Topic < *partitions[j].Topic
+		}
+		return partitions[i].Partition < partitions[j].Partition
+	})
+	return partitions
+}
+
+// RebalanceLeaders moves the leadership of every partition of the configured topics
+// back to its preferred replica with a preferred leader election, e.g. after a rolling
+// restart left most leaders on a few brokers. Replicas themselves are not moved.
+// It returns the number of partitions elected and failed, with per-partition failures
+// reported through a TopicErrors keyed by topic.
+func (tm *TopicManager) RebalanceLeaders(ctx context.Context, topicSpecs []kafka.TopicSpecification,
+	skipped []string) (int, int, error) {
+	existingTopics, err := tm.GetExistingTopics(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	partitions := tm.leaderImbalance(existingTopics, topicSpecs, skipped)
+	if len(partitions) == 0 {
+		fmt.Fprintln(messageOut, "⚖️  Every partition leader is already on its preferred replica")
+		return 0, 0, nil
+	}
+	fmt.Fprintf(messageOut, "⚖️  Electing preferred leaders for %d partitions...\n", len(partitions))
+
+	result, err := tm.electPreferredLeaders(ctx, partitions)
+	if err != nil {
+		return 0, len(partitions), fmt.Errorf("failed to elect leaders: %w", err)
+	}
+
+	elected := 0
+	failures := make(TopicErrors)
+	progress := newProgressReporter("elect", len(result.TopicPartitions), tm.opts)
+	for _, partition := range result.TopicPartitions {
+		topic := *partition.Topic
+		var kafkaErr kafka.Error
+		if partition.Error != nil && !errors.As(partition.Error, &kafkaErr) {
+			kafkaErr = kafka.NewError(kafka.ErrUnknown, partition.Error.Error(), false)
+		}
+		// A partition whose leader moved back on its own needs no election
+		if partition.Error != nil && kafkaErr.Code() != kafka.ErrElectionNotNeeded {
+			progress.failed(topic, kafkaErr,
+				fmt.Sprintf("❌ Failed to elect the preferred leader of '%s' partition %d: %v", topic, partition.Partition, kafkaErr))
+			failures[topic] = kafkaErr
+			continue
+		}
+		progress.succeeded(topic, "elected",
+			fmt.Sprintf("⚖️  Elected the preferred leader of '%s' partition %d", topic, partition.Partition))
+		elected++
+	}
+
+	if len(failures) > 0 {
+		return elected, len(result.TopicPartitions) - elected, failures
+	}
+	return elected, 0, nil
+}
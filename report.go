@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// writeCreatedTopicsFile records the newly created topics for downstream automation,
+// as a JSON array when the path ends in .json and one name per line otherwise
+func writeCreatedTopicsFile(path string, topics []string) error {
+	sorted := append([]string{}, topics...)
+	sort.Strings(sorted)
+
+	var data []byte
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		encoded, err := json.MarshalIndent(sorted, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode created topics: %w", err)
+		}
+		data = append(encoded, '\n')
+	} else {
+		for _, topic := range sorted {
+			data = append(data, topic+"\n"...)
+		}
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write created topics file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// readTopicListFile reads the topic names of a -delete-list file, one per line, such as
+// a -created-file written by an earlier run. Blank lines and lines starting with # are
+// skipped, and repeated names are listed once.
+func readTopicListFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read topic list %s: %w", path, err)
+	}
+
+	var topics []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		topic := strings.TrimSpace(scanner.Text())
+		if topic == "" || strings.HasPrefix(topic, "#") || seen[topic] {
+			continue
+		}
+		seen[topic] = true
+		topics = append(topics, topic)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read topic list %s: %w", path, err)
+	}
+
+	return topics, nil
+}
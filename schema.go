@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// schemaObject is a JSON Schema node; maps keep the output free of empty keywords
+type schemaObject map[string]any
+
+// configSchema returns the JSON Schema of the topics configuration file, for editor
+// and pre-commit validation. It mirrors TopicsConfig and the checks of
+// GetAllTopicConfigs that can be expressed statically.
+func configSchema() schemaObject {
+	count := func(description string) schemaObject {
+		return schemaObject{
+			"description": description,
+			"anyOf": []schemaObject{
+				{"type": "integer", "minimum": 1},
+				{"const": useBrokerDefault},
+			},
+		}
+	}
+	size := func(description string) schemaObject {
+		return schemaObject{
+			"description": description,
+			"type":        []string{"string", "integer"},
+		}
+	}
+
+	policies := make([]string, 0, len(validCleanupPolicies))
+	for policy := range validCleanupPolicies {
+		policies = append(policies, policy)
+	}
+	sort.Strings(policies)
+
+	topic := schemaObject{
+		"type":                 "object",
+		"additionalProperties": false,
+		"required":             []string{"name"},
+		"anyOf": []schemaObject{
+			{"required": []string{"partitions", "replication_factor"}},
+			{"required": []string{"replica_assignment"}},
+		},
+		"properties": schemaObject{
+			"name":        schemaObject{"type": "string", "minLength": 1},
+			"partitions":  count("Partition count, or -1 for the broker default"),
+			"description": schemaObject{"type": "string"},
+			"replication_factor": schemaObject{
+				"description": "Replication factor, -1 for the broker default, or auto",
+				"anyOf": []schemaObject{
+					{"type": "integer", "minimum": 1},
+					{"const": useBrokerDefault},
+					{"const": "auto"},
+				},
+			},
+			"config": schemaObject{
+				"description":          "Topic-level Kafka configs such as retention.ms",
+				"type":                 "object",
+				"additionalProperties": schemaObject{"type": []string{"string", "number", "boolean"}},
+			},
+			"retention": schemaObject{
+				"description": "Duration for retention.ms, e.g. 7d, 2w or 168h",
+				"type":        "string",
+				"pattern":     `^([0-9]+[dw]|([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+)$`,
+			},
+			"retention_bytes": size("Size for retention.bytes, e.g. 1GiB or 512MB"),
+			"segment_bytes":   size("Size for segment.bytes, e.g. 1GiB or 512MB"),
+			"cleanup_policy": schemaObject{
+				"description": "Sets cleanup.policy",
+				"enum":        policies,
+			},
+			"min_insync_replicas": schemaObject{
+				"description": "Sets min.insync.replicas",
+				"type":        "integer",
+				"minimum":     1,
+			},
+			"acls": schemaObject{
+				"description": "ACLs applied to the topic with -with-acls",
+				"type":        "array",
+				"items": schemaObject{
+					"type":                 "object",
+					"additionalProperties": false,
+					"required":             []string{"principal", "operation"},
+					"properties": schemaObject{
+						"principal":  schemaObject{"type": "string", "pattern": "^[^:]+:.+$"},
+						"operation":  schemaObject{"type": "string"},
+						"permission": schemaObject{"enum": []string{"allow", "deny"}},
+						"host":       schemaObject{"type": "string"},
+					},
+				},
+			},
+			"replica_assignment": schemaObject{
+				"description": "Broker IDs of each partition's replicas, preferred leader first",
+				"type":        "array",
+				"minItems":    1,
+				"items": schemaObject{
+					"type":        "array",
+					"minItems":    1,
+					"uniqueItems": true,
+					"items":       schemaObject{"type": "integer", "minimum": 0},
+				},
+			},
+		},
+	}
+	topics := schemaObject{"type": "array", "items": schemaObject{"$ref": "#/$defs/topic"}}
+
+	return schemaObject{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"title":                "kafka-topic-creator topics configuration",
+		"type":                 "object",
+		"additionalProperties": false,
+		"$defs":                schemaObject{"topic": topic},
+		"properties": schemaObject{
+			"version": schemaObject{
+				"description": "Config file format version",
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     configFormatVersion,
+			},
+			"topics": topics,
+			"clusters": schemaObject{
+				"type": "array",
+				"items": schemaObject{
+					"type":                 "object",
+					"additionalProperties": false,
+					"required":             []string{"name"},
+					"oneOf": []schemaObject{
+						{"required": []string{"server"}},
+						{"required": []string{"servers"}},
+					},
+					"properties": schemaObject{
+						"name":   schemaObject{"type": "string", "minLength": 1},
+						"server": schemaObject{"type": "string", "minLength": 1},
+						"servers": schemaObject{
+							"type":     "array",
+							"minItems": 1,
+							"items":    schemaObject{"type": "string", "minLength": 1},
+						},
+						"username_env": schemaObject{"type": "string"},
+						"password_env": schemaObject{"type": "string"},
+					},
+				},
+			},
+			"environments": schemaObject{
+				"type": "object",
+				"additionalProperties": schemaObject{
+					"type":                 "object",
+					"additionalProperties": false,
+					"properties":           schemaObject{"topics": topics},
+				},
+			},
+			"protected": schemaObject{
+				"description": "Topics never altered or deleted",
+				"type":        "array",
+				"items":       schemaObject{"type": "string"},
+			},
+			"defaults": schemaObject{
+				"type":                 "object",
+				"additionalProperties": false,
+				"properties": schemaObject{
+					"config": schemaObject{
+						"description":          "Configs applied to every topic that does not set them",
+						"type":                 "object",
+						"additionalProperties": schemaObject{"type": []string{"string", "number", "boolean"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+// printSchema writes the config file's JSON Schema
+func printSchema(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(configSchema()); err != nil {
+		return fmt.Errorf("failed to encode schema: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// stateFormatVersion is the version of the cluster state files written by export-state
+const stateFormatVersion = 1
+
+// clusterState is the JSON form of a cluster snapshot: its brokers, and each topic's
+// partitions and config overrides. plan -state-file computes the plan against it.
+type clusterState struct {
+	Version    int           `json:"version"`
+	ExportedAt string        `json:"exported_at"`
+	Brokers    []stateBroker `json:"brokers"`
+	Topics     []stateTopic  `json:"topics"`
+}
+
+// stateBroker is a broker of a cluster state file
+type stateBroker struct {
+	ID   int32  `json:"id"`
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+// stateTopic is a topic of a cluster state file with its config overrides
+type stateTopic struct {
+	Name       string            `json:"name"`
+	Partitions []statePartition  `json:"partitions"`
+	Configs    map[string]string `json:"configs,omitempty"`
+}
+
+// statePartition is the leader and replica placement of a partition
+type statePartition struct {
+	ID       int32   `json:"id"`
+	Leader   int32   `json:"leader"`
+	Replicas []int32 `json:"replicas"`
+	Isrs     []int32 `json:"isrs"`
+}
+
+// writeState writes the brokers, topics and config overrides held by the fake as a
+// cluster state file, sorted by topic name and partition
+func (f *FakeAdmin) writeState(w io.Writer) error {
+	f.mu.Lock()
+	state := clusterState{
+		Version:    stateFormatVersion,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Brokers:    make([]stateBroker, 0, len(f.brokers)),
+		Topics:     make([]stateTopic, 0, len(f.topics)),
+	}
+	for _, broker := range f.brokers {
+		state.Brokers = append(state.Brokers, stateBroker{ID: broker.ID, Host: broker.Host, Port: broker.Port})
+	}
+	for name, topic := range f.topics {
+		exported := stateTopic{Name: name, Partitions: make([]statePartition, 0, len(topic.partitions))}
+		for _, partition := range topic.partitions {
+			exported.Partitions = append(exported.Partitions, statePartition{
+				ID:       partition.ID,
+				Leader:   partition.Leader,
+				Replicas: partition.Replicas,
+				Isrs:     partition.Isrs,
+			})
+		}
+		sort.Slice(exported.Partitions, func(i, j int) bool {
+			return exported.Partitions[i].ID < exported.Partitions[j].ID
+		})
+		if len(topic.configs) > 0 {
+			exported.Configs = topic.configs
+		}
+		state.Topics = append(state.Topics, exported)
+	}
+	f.mu.Unlock()
+
+	sort.Slice(state.Topics, func(i, j int) bool { return state.Topics[i].Name < state.Topics[j].Name })
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(state); err != nil {
+		return fmt.Errorf("failed to encode cluster state: %w", err)
+	}
+	return nil
+}
+
+// NewFakeAdminFromState creates a FakeAdmin holding the cluster snapshot in a state
+// file written by export-state, so a plan can be computed without a connection
+func NewFakeAdminFromState(path string) (*FakeAdmin, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file %s: %w", path, err)
+	}
+
+	var state clusterState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	if state.Version < 1 || state.Version > stateFormatVersion {
+		return nil, fmt.Errorf("state file %s has unsupported version %d (this build reads version %d)",
+			path, state.Version, stateFormatVersion)
+	}
+
+	fake := &FakeAdmin{topics: make(map[string]*fakeTopic, len(state.Topics))}
+	for _, broker := range state.Brokers {
+		fake.brokers = append(fake.brokers, kafka.BrokerMetadata{ID: broker.ID, Host: broker.Host, Port: broker.Port})
+	}
+	for _, topic := range state.Topics {
+		if topic.Name == "" {
+			return nil, fmt.Errorf("state file %s has a topic without a name", path)
+		}
+		imported := &fakeTopic{configs: make(map[string]string, len(topic.Configs))}
+		for _, partition := range topic.Partitions {
+			imported.partitions = append(imported.partitions, kafka.PartitionMetadata{
+				ID:       partition.ID,
+				Leader:   partition.Leader,
+				Replicas: partition.Replicas,
+				Isrs:     partition.Isrs,
+			})
+		}
+		for name, value := range topic.Configs {
+			imported.configs[name] = value
+		}
+		fake.topics[topic.Name] = imported
+	}
+
+	return fake, nil
+}
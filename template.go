@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// templateErrorLine extracts the line number from text/template error messages,
+// which look like "template: topics.yaml:12: ..." or "template: topics.yaml:12:5: ..."
+var templateErrorLine = regexp.MustCompile(`template: [^:]*:(\d+)`)
+
+// renderConfigTemplate renders a config file with text/template before it is parsed as
+// YAML. Values from valuesFile are available at the top level (e.g. .Regions) and the
+// process environment as .Env.
+func renderConfigTemplate(name string, data []byte, valuesFile string) ([]byte, error) {
+	values := make(map[string]any)
+	if valuesFile != "" {
+		valuesData, err := os.ReadFile(valuesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read values file %s: %w", valuesFile, err)
+		}
+		if err := yaml.Unmarshal(valuesData, &values); err != nil {
+			return nil, fmt.Errorf("failed to parse values file %s: %w", valuesFile, err)
+		}
+	}
+
+	if _, ok := values["Env"]; !ok {
+		env := make(map[string]string)
+		for _, entry := range os.Environ() {
+			if key, value, ok := strings.Cut(entry, "="); ok {
+				env[key] = value
+			}
+		}
+		values["Env"] = env
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return nil, templateError(name, data, err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, values); err != nil {
+		return nil, templateError(name, data, err)
+	}
+
+	return rendered.Bytes(), nil
+}
+
+// templateError adds the offending source line to a template error when it can be located
+func templateError(name string, data []byte, err error) error {
+	match := templateErrorLine.FindStringSubmatch(err.Error())
+	if match == nil {
+		return fmt.Errorf("failed to render template %s: %w", name, err)
+	}
+
+	lineNumber, _ := strconv.Atoi(match[1])
+	lines := strings.Split(string(data), "\n")
+	if lineNumber < 1 || lineNumber > len(lines) {
+		return fmt.Errorf("failed to render template %s: %w", name, err)
+	}
+
+	return fmt.Errorf("failed to render template %s: %w\n  %d | %s", name, err, lineNumber, lines[lineNumber-1])
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// knownTopicConfigKeys lists the topic-level configuration names accepted by Kafka brokers
+var knownTopicConfigKeys = map[string]bool{
+	"cleanup.policy":                          true,
+	"compression.gzip.level":                  true,
+	"compression.lz4.level":                   true,
+	"compression.type":                        true,
+	"compression.zstd.level":                  true,
+	"delete.retention.ms":                     true,
+	"file.delete.delay.ms":                    true,
+	"flush.messages":                          true,
+	"flush.ms":                                true,
+	"follower.replication.throttled.replicas": true,
+	"index.interval.bytes":                    true,
+	"leader.replication.throttled.replicas":   true,
+	"local.retention.bytes":                   true,
+	"local.retention.ms":                      true,
+	"max.compaction.lag.ms":                   true,
+	"max.message.bytes":                       true,
+	"message.downconversion.enable":           true,
+	"message.format.version":                  true,
+	"message.timestamp.after.max.ms":          true,
+	"message.timestamp.before.max.ms":         true,
+	"message.timestamp.difference.max.ms":     true,
+	"message.timestamp.type":                  true,
+	"min.cleanable.dirty.ratio":               true,
+	"min.compaction.lag.ms":                   true,
+	"min.insync.replicas":                     true,
+	"preallocate":                             true,
+	"remote.log.copy.disable":                 true,
+	"remote.log.delete.on.disable":            true,
+	"remote.storage.enable":                   true,
+	"retention.bytes":                         true,
+	"retention.ms":                            true,
+	"segment.bytes":                           true,
+	"segment.index.bytes":                     true,
+	"segment.jitter.ms":                       true,
+	"segment.ms":                              true,
+	"unclean.leader.election.enable":          true,
+}
+
+// resolveTopicConfig returns the topic's config map with the human-friendly fields
+// converted into their Kafka config keys. Setting both a friendly field and its raw
+// key is ambiguous and rejected.
+func resolveTopicConfig(topic TopicConfig) (map[string]string, error) {
+	config := make(map[string]string, len(topic.Config)+1)
+	for key, value := range topic.Config {
+		config[key] = value
+	}
+
+	if topic.Retention != "" {
+		if _, ok := config["retention.ms"]; ok {
+			return nil, fmt.Errorf("topic '%s' sets both retention and config retention.ms, use only one", topic.Name)
+		}
+		retention, err := parseRetention(topic.Retention)
+		if err != nil {
+			return nil, fmt.Errorf("topic '%s' has invalid retention '%s': %w", topic.Name, topic.Retention, err)
+		}
+		config["retention.ms"] = strconv.FormatInt(retention.Milliseconds(), 10)
+	}
+
+	if topic.CleanupPolicy != "" {
+		if _, ok := config["cleanup.policy"]; ok {
+			return nil, fmt.Errorf("topic '%s' sets both cleanup_policy and config cleanup.policy, use only one", topic.Name)
+		}
+		config["cleanup.policy"] = topic.CleanupPolicy
+	}
+
+	if topic.MinInsyncReplicas != 0 {
+		if _, ok := config["min.insync.replicas"]; ok {
+			return nil, fmt.Errorf("topic '%s' sets both min_insync_replicas and config min.insync.replicas, use only one", topic.Name)
+		}
+		config["min.insync.replicas"] = strconv.Itoa(topic.MinInsyncReplicas)
+	}
+
+	sizeFields := []struct {
+		field string
+		key   string
+		value string
+	}{
+		{"retention_bytes", "retention.bytes", topic.RetentionBytes},
+		{"segment_bytes", "segment.bytes", topic.SegmentBytes},
+	}
+	for _, size := range sizeFields {
+		if size.value == "" {
+			continue
+		}
+		if _, ok := config[size.key]; ok {
+			return nil, fmt.Errorf("topic '%s' sets both %s and config %s, use only one", topic.Name, size.field, size.key)
+		}
+		bytes, err := parseByteSize(size.value)
+		if err != nil {
+			return nil, fmt.Errorf("topic '%s' has invalid %s '%s': %w", topic.Name, size.field, size.value, err)
+		}
+		config[size.key] = strconv.FormatInt(bytes, 10)
+	}
+
+	if len(config) == 0 {
+		return nil, nil
+	}
+
+	return config, nil
+}
+
+// mergeDefaultConfig adds the defaults.config keys a topic's resolved config does not set
+func mergeDefaultConfig(config, defaults map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return config
+	}
+
+	merged := make(map[string]string, len(config)+len(defaults))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range config {
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// parseRetention parses a duration that, besides Go duration syntax (168h, 90m),
+// accepts whole days and weeks such as 7d or 2w
+func parseRetention(value string) (time.Duration, error) {
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+
+	var duration time.Duration
+	if unit, ok := units[value[len(value)-1:]]; ok {
+		count, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil {
+			return 0, fmt.Errorf("expected a whole number of %s, e.g. 7d", value[len(value)-1:])
+		}
+		// Check the bounds first, the product would silently wrap around
+		if count < 1 {
+			return 0, fmt.Errorf("retention must be positive")
+		}
+		if maxCount := math.MaxInt64 / int64(unit); int64(count) > maxCount {
+			return 0, fmt.Errorf("retention out of range (at most %d%s)", maxCount, value[len(value)-1:])
+		}
+		duration = time.Duration(count) * unit
+	} else {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("expected a duration such as 7d or 168h")
+		}
+		duration = parsed
+	}
+
+	if duration <= 0 {
+		return 0, fmt.Errorf("retention must be positive")
+	}
+	// retention.ms holds whole milliseconds, 500us would truncate to 0 and delete everything
+	if duration%time.Millisecond != 0 {
+		return 0, fmt.Errorf("retention must be a whole number of milliseconds")
+	}
+
+	return duration, nil
+}
+
+// byteSizeUnits maps size suffixes to their multiplier: SI units are powers of 1000,
+// IEC units (KiB, MiB, ...) powers of 1024
+var byteSizeUnits = map[string]int64{
+	"":    1,
+	"B":   1,
+	"KB":  1000,
+	"MB":  1000 * 1000,
+	"GB":  1000 * 1000 * 1000,
+	"TB":  1000 * 1000 * 1000 * 1000,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+	"TiB": 1 << 40,
+}
+
+// byteSizePattern splits a size such as 512MB or 1.5GiB into number and unit
+var byteSizePattern = regexp.MustCompile(`^\s*([0-9]+(?:\.[0-9]+)?)\s*([A-Za-z]*)\s*$`)
+
+// parseByteSize parses a size with an optional unit suffix into a number of bytes
+func parseByteSize(value string) (int64, error) {
+	match := byteSizePattern.FindStringSubmatch(value)
+	if match == nil {
+		return 0, fmt.Errorf("expected a size such as 1GiB or 512MB")
+	}
+
+	multiplier, ok := byteSizeUnits[match[2]]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit '%s' (expected B, KB, MB, GB, TB, KiB, MiB, GiB or TiB)", match[2])
+	}
+
+	// Multiply in integers, checking the bounds first since the product would wrap around
+	whole, fraction, _ := strings.Cut(match[1], ".")
+	count, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || count > math.MaxInt64/multiplier {
+		return 0, fmt.Errorf("size out of range")
+	}
+	bytes := count * multiplier
+
+	if fraction != "" {
+		// Digits past the 19th are worth less than a byte in every unit
+		fraction = fraction[:min(len(fraction), 19)]
+		digits, _ := strconv.ParseUint(fraction, 10, 64)
+		scale := uint64(1)
+		for range fraction {
+			scale *= 10
+		}
+		hi, lo := bits.Mul64(digits, uint64(multiplier))
+		partial, _ := bits.Div64(hi, lo, scale)
+		if int64(partial) > math.MaxInt64-bytes {
+			return 0, fmt.Errorf("size out of range")
+		}
+		bytes += int64(partial)
+	}
+
+	if bytes < 1 {
+		return 0, fmt.Errorf("size out of range")
+	}
+
+	return bytes, nil
+}
+
+// validCleanupPolicies lists the accepted cleanup.policy values
+var validCleanupPolicies = map[string]bool{
+	"delete":         true,
+	"compact":        true,
+	"compact,delete": true,
+	"delete,compact": true,
+}
+
+// validateTopicConfig checks the per-topic config map of a single topic
+func validateTopicConfig(topic TopicConfig, opts LoadOptions) error {
+	// Sort keys so the reported error is deterministic
+	keys := make([]string, 0, len(topic.Config))
+	for key := range topic.Config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !opts.AllowUnknownConfig && !knownTopicConfigKeys[key] {
+			return fmt.Errorf("topic '%s' has unknown config key '%s' (use -allow-unknown-config to skip this check)", topic.Name, key)
+		}
+	}
+
+	// A min.insync.replicas above the replication factor makes the topic unwritable
+	if value, ok := topic.Config["min.insync.replicas"]; ok {
+		minISR, err := strconv.Atoi(value)
+		if err != nil || minISR < 1 {
+			return fmt.Errorf("topic '%s' has invalid min.insync.replicas '%s': must be a positive integer", topic.Name, value)
+		}
+		// Broker-default and auto factors are only known once connected
+		if topic.ReplicationFactor > 0 && minISR > int(topic.ReplicationFactor) {
+			return fmt.Errorf("topic '%s' has min.insync.replicas %d greater than replication factor %d, producers with acks=all would be rejected",
+				topic.Name, minISR, topic.ReplicationFactor)
+		}
+	}
+
+	if policy, ok := topic.Config["cleanup.policy"]; ok {
+		if !validCleanupPolicies[policy] {
+			return fmt.Errorf("topic '%s' has invalid cleanup.policy '%s' (expected delete, compact or compact,delete)", topic.Name, policy)
+		}
+
+		// Compaction timing is surprising without explicit tuning
+		if strings.Contains(policy, "compact") {
+			for _, key := range []string{"min.cleanable.dirty.ratio", "segment.ms"} {
+				if _, ok := topic.Config[key]; !ok {
+					fmt.Fprintf(statusOut, "⚠️  Compacted topic '%s' does not set %s, broker defaults decide when compaction runs\n", topic.Name, key)
+				}
+			}
+		}
+	}
+
+	if value, ok := topic.Config["max.message.bytes"]; ok {
+		if maxBytes, err := strconv.Atoi(value); err != nil || maxBytes < 1 {
+			return fmt.Errorf("topic '%s' has invalid max.message.bytes '%s': must be a positive integer", topic.Name, value)
+		}
+	}
+
+	return nil
+}
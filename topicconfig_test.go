@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRetention(t *testing.T) {
+	cases := []struct {
+		value string
+		want  time.Duration
+		err   string // substring of the expected error, empty when the value is valid
+	}{
+		{value: "7d", want: 7 * 24 * time.Hour},
+		{value: "1d", want: 24 * time.Hour},
+		{value: "2w", want: 14 * 24 * time.Hour},
+		{value: "168h", want: 168 * time.Hour},
+		{value: "90m", want: 90 * time.Minute},
+		{value: "1ms", want: time.Millisecond},
+		{value: "1500ms", want: 1500 * time.Millisecond},
+		{value: "106751d", want: 106751 * 24 * time.Hour},
+		{value: "15250w", want: 15250 * 7 * 24 * time.Hour},
+		{value: "106752d", err: "retention out of range (at most 106751d)"},
+		{value: "15251w", err: "retention out of range (at most 15250w)"},
+		{value: "99999999999999999999d", err: "expected a whole number of d"},
+		{value: "0d", err: "retention must be positive"},
+		{value: "-1w", err: "retention must be positive"},
+		{value: "0s", err: "retention must be positive"},
+		{value: "-5m", err: "retention must be positive"},
+		{value: "500us", err: "whole number of milliseconds"},
+		{value: "999999ns", err: "whole number of milliseconds"},
+		{value: "1.5ms", err: "whole number of milliseconds"},
+		{value: "1.5d", err: "expected a whole number of d"},
+		{value: "d", err: "expected a whole number of d"},
+		{value: "7 days", err: "expected a duration"},
+		{value: "7", err: "expected a duration"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			got, err := parseRetention(tc.value)
+			switch {
+			case tc.err == "" && err != nil:
+				t.Fatalf("parseRetention(%q) failed: %v", tc.value, err)
+			case tc.err == "" && got != tc.want:
+				t.Fatalf("parseRetention(%q) = %v, want %v", tc.value, got, tc.want)
+			case tc.err != "" && err == nil:
+				t.Fatalf("parseRetention(%q) = %v, want an error containing %q", tc.value, got, tc.err)
+			case tc.err != "" && !strings.Contains(err.Error(), tc.err):
+				t.Fatalf("parseRetention(%q) error %q, want it to contain %q", tc.value, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	cases := []struct {
+		value string
+		want  int64
+		err   string // substring of the expected error, empty when the value is valid
+	}{
+		{value: "1048576", want: 1048576},
+		{value: "512B", want: 512},
+		{value: "1KB", want: 1000},
+		{value: "512MB", want: 512 * 1000 * 1000},
+		{value: "2GB", want: 2 * 1000 * 1000 * 1000},
+		{value: "1TB", want: 1000 * 1000 * 1000 * 1000},
+		{value: "1KiB", want: 1 << 10},
+		{value: "100MiB", want: 100 << 20},
+		{value: "1GiB", want: 1 << 30},
+		{value: " 2 TiB ", want: 2 << 40},
+		{value: "1.5GiB", want: 3 << 29},
+		{value: "0.5KB", want: 500},
+		{value: "1.0000000000000000000009KiB", want: 1024},
+		{value: "8388607TiB", want: 8388607 << 40},
+		{value: "0", err: "size out of range"},
+		{value: "0.0001B", err: "size out of range"},
+		{value: "8388608TiB", err: "size out of range"},
+		{value: "9223372036854775808", err: "size out of range"},
+		{value: "9223372.9TB", err: "size out of range"},
+		{value: "1PB", err: "unknown unit 'PB'"},
+		{value: "1gb", err: "unknown unit 'gb'"},
+		{value: "-1GB", err: "expected a size"},
+		{value: "1.GB", err: "expected a size"},
+		{value: "GB", err: "expected a size"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			got, err := parseByteSize(tc.value)
+			switch {
+			case tc.err == "" && err != nil:
+				t.Fatalf("parseByteSize(%q) failed: %v", tc.value, err)
+			case tc.err == "" && got != tc.want:
+				t.Fatalf("parseByteSize(%q) = %d, want %d", tc.value, got, tc.want)
+			case tc.err != "" && err == nil:
+				t.Fatalf("parseByteSize(%q) = %d, want an error containing %q", tc.value, got, tc.err)
+			case tc.err != "" && !strings.Contains(err.Error(), tc.err):
+				t.Fatalf("parseByteSize(%q) error %q, want it to contain %q", tc.value, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestCleanupPolicy(t *testing.T) {
+	cases := []struct {
+		name     string
+		topic    TopicConfig
+		err      string // substring of the expected error, empty when the topic is valid
+		warnings int    // compaction tuning warnings expected
+	}{
+		{
+			name:  "delete",
+			topic: TopicConfig{Name: "orders", CleanupPolicy: "delete"},
+		},
+		{
+			name:     "compact without tuning",
+			topic:    TopicConfig{Name: "orders", CleanupPolicy: "compact"},
+			warnings: 2,
+		},
+		{
+			name: "compact,delete with tuning",
+			topic: TopicConfig{Name: "orders", CleanupPolicy: "compact,delete",
+				Config: map[string]string{"min.cleanable.dirty.ratio": "0.1", "segment.ms": "3600000"}},
+		},
+		{
+			name:     "delete,compact through config",
+			topic:    TopicConfig{Name: "orders", Config: map[string]string{"cleanup.policy": "delete,compact", "segment.ms": "3600000"}},
+			warnings: 1,
+		},
+		{
+			name:  "unknown policy",
+			topic: TopicConfig{Name: "orders", CleanupPolicy: "compacted"},
+			err:   "invalid cleanup.policy 'compacted'",
+		},
+		{
+			name:  "set twice",
+			topic: TopicConfig{Name: "orders", CleanupPolicy: "compact", Config: map[string]string{"cleanup.policy": "delete"}},
+			err:   "sets both cleanup_policy and config cleanup.policy",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var warnings bytes.Buffer
+			previous := statusOut
+			statusOut = &warnings
+			defer func() { statusOut = previous }()
+
+			config, err := resolveTopicConfig(tc.topic)
+			if err == nil {
+				tc.topic.Config = config
+				err = validateTopicConfig(tc.topic, LoadOptions{})
+			}
+			switch {
+			case tc.err == "" && err != nil:
+				t.Fatalf("cleanup policy rejected: %v", err)
+			case tc.err != "" && err == nil:
+				t.Fatalf("cleanup policy accepted, want an error containing %q", tc.err)
+			case tc.err != "" && !strings.Contains(err.Error(), tc.err):
+				t.Fatalf("cleanup policy error %q, want it to contain %q", err, tc.err)
+			}
+			if got := strings.Count(warnings.String(), "broker defaults decide when compaction runs"); got != tc.warnings {
+				t.Errorf("printed %d compaction tuning warnings, want %d:\n%s", got, tc.warnings, warnings.String())
+			}
+		})
+	}
+}
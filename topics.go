@@ -1,60 +1,463 @@
 package main
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
-	"os"
+	"io"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
-	"gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
 )
 
 // TopicConfig represents a single topic configuration from YAML
 type TopicConfig struct {
-	Name              string `yaml:"name"`
-	Partitions        int    `yaml:"partitions"`
-	ReplicationFactor int    `yaml:"replication_factor"`
-	Description       string `yaml:"description,omitempty"`
+	Name              string            `yaml:"name"`
+	Partitions        int               `yaml:"partitions"`
+	ReplicationFactor ReplicationFactor `yaml:"replication_factor"`
+	Description       string            `yaml:"description,omitempty"`
+
+	// Config holds topic-level Kafka configs such as retention.ms or cleanup.policy
+	Config map[string]string `yaml:"config,omitempty"`
+
+	// Retention is a human-friendly alternative to retention.ms, e.g. 7d or 168h
+	Retention string `yaml:"retention,omitempty"`
+
+	// Human-friendly alternatives to retention.bytes and segment.bytes, e.g. 1GiB or 512MB
+	RetentionBytes string `yaml:"retention_bytes,omitempty"`
+	SegmentBytes   string `yaml:"segment_bytes,omitempty"`
+
+	// CleanupPolicy sets cleanup.policy: delete, compact or compact,delete
+	CleanupPolicy string `yaml:"cleanup_policy,omitempty"`
+
+	// MinInsyncReplicas sets min.insync.replicas
+	MinInsyncReplicas int `yaml:"min_insync_replicas,omitempty"`
+
+	// ReplicaAssignment lists the broker IDs of each partition's replicas, preferred
+	// leader first; partitions and replication_factor are inferred from it
+	ReplicaAssignment [][]int32 `yaml:"replica_assignment,omitempty"`
+
+	// ACLs are applied to the topic with -with-acls
+	ACLs []TopicACL `yaml:"acls,omitempty"`
+}
+
+// useBrokerDefault is the partitions/replication_factor sentinel that defers to the broker's defaults
+const useBrokerDefault = -1
+
+// autoReplicationFactor is the replication_factor sentinel for "auto", resolved at apply
+// time to the smaller of -replication-max and the cluster's broker count
+const autoReplicationFactor = -2
+
+// ReplicationFactor is a topic's replication factor, written in YAML as a number or "auto"
+type ReplicationFactor int
+
+// UnmarshalYAML accepts a number or the string "auto"
+func (r *ReplicationFactor) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode && value.Value == "auto" {
+		*r = autoReplicationFactor
+		return nil
+	}
+
+	n, err := strconv.Atoi(value.Value)
+	if value.Kind != yaml.ScalarNode || err != nil {
+		// A TypeError is collected with the decoder's own errors and located like them
+		return &yaml.TypeError{Errors: []string{
+			fmt.Sprintf("line %d: invalid replication_factor '%s': expected a number or auto", value.Line, value.Value),
+		}}
+	}
+	*r = ReplicationFactor(n)
+	return nil
+}
+
+// MarshalYAML writes the auto sentinel back as "auto"
+func (r ReplicationFactor) MarshalYAML() (any, error) {
+	if r == autoReplicationFactor {
+		return "auto", nil
+	}
+	return int(r), nil
+}
+
+// ClusterConfig describes one target cluster when a config applies to several clusters
+type ClusterConfig struct {
+	Name   string `yaml:"name"`
+	Server string `yaml:"server"` // One or more comma-separated bootstrap servers
+
+	// Servers lists the bootstrap servers, as an alternative to a comma-separated server
+	Servers []string `yaml:"servers,omitempty"`
+
+	// Names of environment variables holding this cluster's credentials; when
+	// unset, the global KAFKA_USERNAME/KAFKA_PASSWORD settings are used
+	UsernameEnv string `yaml:"username_env,omitempty"`
+	PasswordEnv string `yaml:"password_env,omitempty"`
 }
 
+// EnvironmentConfig holds the topics specific to one environment (e.g. dev or prod)
+type EnvironmentConfig struct {
+	Topics []TopicConfig `yaml:"topics"`
+}
+
+// configFormatVersion is the newest config file format this tool understands; files
+// without a version field are read as version 1
+const configFormatVersion = 1
+
 // TopicsConfig represents the complete YAML configuration
 type TopicsConfig struct {
-	Topics []TopicConfig `yaml:"topics"`
+	// Version is the config file format, bumped on breaking changes to it
+	Version int `yaml:"version,omitempty"`
+
+	Clusters []ClusterConfig `yaml:"clusters,omitempty"`
+	Topics   []TopicConfig   `yaml:"topics"` // Shared by every environment
+
+	Environments map[string]EnvironmentConfig `yaml:"environments,omitempty"`
+
+	// Protected names topics that sync and delete must never alter or delete
+	Protected []string `yaml:"protected,omitempty"`
+
+	Defaults TopicDefaults `yaml:"defaults,omitempty"`
+}
+
+// TopicDefaults holds the values applied to every topic that does not set them
+type TopicDefaults struct {
+	// Config is merged into each topic's config map, topic-level keys winning
+	Config map[string]string `yaml:"config,omitempty"`
 }
 
-// GetAllTopicConfigs returns the list of all topics with their configurations from YAML file
-func GetAllTopicConfigs(configFile string) ([]kafka.TopicSpecification, error) {
-	// Read the YAML config file
-	data, err := os.ReadFile(configFile)
+// LoadOptions controls how a topics configuration file is validated
+type LoadOptions struct {
+	// AllowUnknownConfig accepts per-topic config keys missing from knownTopicConfigKeys,
+	// for forward compatibility with newer Kafka versions
+	AllowUnknownConfig bool
+
+	// MaxPartitions is a soft limit on partitions per topic (0 disables the check);
+	// exceeding it is an error unless AllowExcessPartitions is set
+	MaxPartitions         int
+	AllowExcessPartitions bool
+
+	// Environment selects an entry of the environments block, merged over the shared topics
+	Environment string
+
+	// Template renders the file with text/template before parsing, using values from
+	// ValuesFile and the environment
+	Template   bool
+	ValuesFile string
+
+	// Partitions and ReplicationFactor, when positive, replace the value of every topic
+	Partitions        int
+	ReplicationFactor int
+
+	// Lenient ignores unknown fields in the file instead of rejecting them, for files
+	// written for newer versions of this tool
+	Lenient bool
+
+	// PolicyFile names a ConfigPolicy whose allow-list restricts the config keys topics
+	// may set (empty for no restriction)
+	PolicyFile string
+}
+
+// readTopicsFile reads, optionally renders, and parses a YAML topics configuration file
+func readTopicsFile(configFile string, opts LoadOptions) (TopicsConfig, error) {
+	var config TopicsConfig
+
+	// Read the YAML config file, or download it when given as a URL
+	data, err := readConfigSource(configFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file %s: %w", configFile, err)
+		return config, err
 	}
 
-	// Parse the YAML content
-	var config TopicsConfig
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file %s: %w", configFile, err)
+	if opts.Template {
+		data, err = renderConfigTemplate(filepath.Base(configFile), data, opts.ValuesFile)
+		if err != nil {
+			return config, err
+		}
+	}
+
+	// Parse the YAML content, keeping the node tree to locate decoding errors
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return config, fmt.Errorf("failed to parse config file %s: %w", configFile, err)
+	}
+
+	// Check the format version first, since a newer format may use unknown fields
+	var header struct {
+		Version int `yaml:"version"`
+	}
+	if err := root.Decode(&header); err == nil && (header.Version < 0 || header.Version > configFormatVersion) {
+		return config, fmt.Errorf("config file %s uses format version %d, but this tool supports up to version %d; upgrade kafka-topic-creator",
+			configFile, header.Version, configFormatVersion)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(!opts.Lenient)
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return config, fmt.Errorf("failed to parse config file %s: %w", configFile, locateDecodeErrors(&root, err))
+	}
+
+	return config, nil
+}
+
+// GetClusterConfigs returns the clusters declared in the parsed config, or nil when it
+// targets the single cluster configured through the environment
+func GetClusterConfigs(config TopicsConfig) ([]ClusterConfig, error) {
+	clusters := slices.Clone(config.Clusters)
+	seen := make(map[string]bool)
+	for i, cluster := range clusters {
+		if cluster.Name == "" {
+			return nil, fmt.Errorf("cluster #%d must have a name", i+1)
+		}
+		if cluster.Server != "" && len(cluster.Servers) > 0 {
+			return nil, fmt.Errorf("cluster '%s' sets both server and servers, use only one", cluster.Name)
+		}
+		if len(cluster.Servers) > 0 {
+			cluster.Server = strings.Join(cluster.Servers, ",")
+		}
+		if cluster.Server == "" {
+			return nil, fmt.Errorf("cluster '%s' must have a server", cluster.Name)
+		}
+		servers, err := normalizeServers(cluster.Server)
+		if err != nil {
+			return nil, fmt.Errorf("cluster '%s' has an invalid server list: %w", cluster.Name, err)
+		}
+		clusters[i].Server = servers
+		if seen[cluster.Name] {
+			return nil, fmt.Errorf("cluster '%s' is defined more than once", cluster.Name)
+		}
+		seen[cluster.Name] = true
+	}
+
+	return clusters, nil
+}
+
+// GetAllTopicConfigs returns the list of all topics with their configurations from the
+// parsed config
+func GetAllTopicConfigs(config TopicsConfig, opts LoadOptions) ([]kafka.TopicSpecification, error) {
+	topics, err := resolveEnvironmentTopics(config, opts.Environment)
+	if err != nil {
+		return nil, err
+	}
+
+	var allowedKeys map[string]bool
+	if opts.PolicyFile != "" {
+		policy, err := readConfigPolicy(opts.PolicyFile)
+		if err != nil {
+			return nil, err
+		}
+		allowedKeys = policy.allowedKeys(opts.Environment)
+	}
+
+	if opts.Partitions > 0 {
+		fmt.Fprintf(statusOut, "⚠️  Partition override in effect: every topic uses %d partitions (-partitions)\n", opts.Partitions)
+	}
+	if opts.ReplicationFactor > 0 {
+		fmt.Fprintf(statusOut, "⚠️  Replication override in effect: every topic uses replication factor %d (-replication-factor)\n", opts.ReplicationFactor)
 	}
 
 	// Validate and convert to Kafka TopicSpecifications
 	var topicSpecs []kafka.TopicSpecification
-	for _, topic := range config.Topics {
+	for _, topic := range topics {
+		if opts.Partitions > 0 {
+			topic.Partitions = opts.Partitions
+		}
+		if opts.ReplicationFactor > 0 {
+			topic.ReplicationFactor = ReplicationFactor(opts.ReplicationFactor)
+		}
+
 		// Validate topic configuration
 		if topic.Name == "" {
 			return nil, fmt.Errorf("topic name cannot be empty")
 		}
-		if topic.Partitions <= 0 {
-			return nil, fmt.Errorf("topic '%s' must have at least 1 partition", topic.Name)
+		if err := resolveReplicaAssignment(&topic); err != nil {
+			return nil, err
+		}
+		if topic.Partitions <= 0 && topic.Partitions != useBrokerDefault {
+			return nil, fmt.Errorf("topic '%s' must have at least 1 partition (or -1 for the broker default)", topic.Name)
+		}
+		if opts.MaxPartitions > 0 && topic.Partitions > opts.MaxPartitions {
+			if !opts.AllowExcessPartitions {
+				return nil, fmt.Errorf("topic '%s' requests %d partitions, above the limit of %d (use -force to override)",
+					topic.Name, topic.Partitions, opts.MaxPartitions)
+			}
+			fmt.Fprintf(statusOut, "⚠️  Topic '%s' requests %d partitions, above the limit of %d\n", topic.Name, topic.Partitions, opts.MaxPartitions)
+		}
+		if topic.ReplicationFactor <= 0 && topic.ReplicationFactor != useBrokerDefault && topic.ReplicationFactor != autoReplicationFactor {
+			return nil, fmt.Errorf("topic '%s' must have at least 1 replication factor (or -1 for the broker default, or auto)", topic.Name)
 		}
-		if topic.ReplicationFactor <= 0 {
-			return nil, fmt.Errorf("topic '%s' must have at least 1 replication factor", topic.Name)
+		topicConfig, err := resolveTopicConfig(topic)
+		if err != nil {
+			return nil, err
+		}
+		topicConfig = mergeDefaultConfig(topicConfig, config.Defaults.Config)
+		topic.Config = topicConfig
+		if err := validateTopicConfig(topic, opts); err != nil {
+			return nil, err
+		}
+		if err := checkConfigPolicy(topic.Name, topic.Config, allowedKeys, opts.PolicyFile); err != nil {
+			return nil, err
+		}
+		if _, err := topicACLBindings(topic); err != nil {
+			return nil, err
 		}
 
 		topicSpecs = append(topicSpecs, kafka.TopicSpecification{
 			Topic:             topic.Name,
 			NumPartitions:     topic.Partitions,
-			ReplicationFactor: topic.ReplicationFactor,
+			ReplicationFactor: int(topic.ReplicationFactor),
+			ReplicaAssignment: topic.ReplicaAssignment,
+			Config:            topic.Config,
 		})
 	}
 
+	if err := validateResolvedSpecs(topicSpecs); err != nil {
+		return nil, err
+	}
+
 	return topicSpecs, nil
 }
+
+// validateResolvedSpecs re-checks the final specs, after environments, templates,
+// overrides and defaults are merged: every topic is defined once and its replica
+// assignment covers its partitions. A name listed twice, e.g. by a template loop or
+// twice in one environment, would otherwise reach Kafka as an ambiguous request.
+func validateResolvedSpecs(topicSpecs []kafka.TopicSpecification) error {
+	first := make(map[string]kafka.TopicSpecification, len(topicSpecs))
+	for _, spec := range topicSpecs {
+		if previous, ok := first[spec.Topic]; ok {
+			switch {
+			case previous.NumPartitions != spec.NumPartitions:
+				return fmt.Errorf("topic '%s' is defined more than once with conflicting partition counts (%s and %s)",
+					spec.Topic, countLabel(previous.NumPartitions), countLabel(spec.NumPartitions))
+			case previous.ReplicationFactor != spec.ReplicationFactor:
+				return fmt.Errorf("topic '%s' is defined more than once with conflicting replication factors (%s and %s)",
+					spec.Topic, countLabel(previous.ReplicationFactor), countLabel(spec.ReplicationFactor))
+			}
+			return fmt.Errorf("topic '%s' is defined more than once", spec.Topic)
+		}
+		first[spec.Topic] = spec
+
+		if spec.ReplicaAssignment != nil && len(spec.ReplicaAssignment) != spec.NumPartitions {
+			return fmt.Errorf("topic '%s' resolves to %d partitions but its replica_assignment lists %d",
+				spec.Topic, spec.NumPartitions, len(spec.ReplicaAssignment))
+		}
+	}
+
+	return nil
+}
+
+// resolveReplicaAssignment checks that every partition of a manual replica assignment
+// lists the same number of distinct brokers, and infers the topic's partitions and
+// replication factor from it; values given alongside the assignment must agree with it
+func resolveReplicaAssignment(topic *TopicConfig) error {
+	if topic.ReplicaAssignment == nil {
+		return nil
+	}
+	if len(topic.ReplicaAssignment) == 0 {
+		return fmt.Errorf("topic '%s' replica_assignment must list at least one partition", topic.Name)
+	}
+
+	replicas := len(topic.ReplicaAssignment[0])
+	for partition, brokers := range topic.ReplicaAssignment {
+		if len(brokers) == 0 {
+			return fmt.Errorf("topic '%s' replica_assignment partition %d lists no brokers", topic.Name, partition)
+		}
+		if len(brokers) != replicas {
+			return fmt.Errorf("topic '%s' replica_assignment partition %d has %d replicas, partition 0 has %d",
+				topic.Name, partition, len(brokers), replicas)
+		}
+		seen := make(map[int32]bool, len(brokers))
+		for _, broker := range brokers {
+			if seen[broker] {
+				return fmt.Errorf("topic '%s' replica_assignment partition %d lists broker %d twice", topic.Name, partition, broker)
+			}
+			seen[broker] = true
+		}
+	}
+
+	if topic.Partitions != 0 && topic.Partitions != len(topic.ReplicaAssignment) {
+		return fmt.Errorf("topic '%s' sets %d partitions but its replica_assignment lists %d",
+			topic.Name, topic.Partitions, len(topic.ReplicaAssignment))
+	}
+	if topic.ReplicationFactor != 0 && int(topic.ReplicationFactor) != replicas {
+		return fmt.Errorf("topic '%s' sets replication_factor %s but its replica_assignment has %d replicas per partition",
+			topic.Name, countLabel(int(topic.ReplicationFactor)), replicas)
+	}
+
+	topic.Partitions = len(topic.ReplicaAssignment)
+	topic.ReplicationFactor = ReplicationFactor(replicas)
+	return nil
+}
+
+// selectTopicSpecs returns the specs of the named topics, in config order, failing
+// when a name is not defined in the config
+func selectTopicSpecs(topicSpecs []kafka.TopicSpecification, names []string) ([]kafka.TopicSpecification, error) {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	var selected []kafka.TopicSpecification
+	for _, spec := range topicSpecs {
+		if wanted[spec.Topic] {
+			selected = append(selected, spec)
+			delete(wanted, spec.Topic)
+		}
+	}
+
+	if len(wanted) > 0 {
+		missing := make([]string, 0, len(wanted))
+		for name := range wanted {
+			missing = append(missing, name)
+		}
+		sort.Strings(missing)
+		return nil, fmt.Errorf("topics not defined in the config: %s", strings.Join(missing, ", "))
+	}
+
+	return selected, nil
+}
+
+// resolveEnvironmentTopics merges the selected environment's topics over the shared
+// topics; an environment topic replaces a shared topic with the same name
+func resolveEnvironmentTopics(config TopicsConfig, environment string) ([]TopicConfig, error) {
+	if environment == "" {
+		if len(config.Environments) > 0 {
+			return nil, fmt.Errorf("config defines environments (%s), select one with -env", environmentNames(config))
+		}
+		return config.Topics, nil
+	}
+
+	env, ok := config.Environments[environment]
+	if !ok {
+		if len(config.Environments) == 0 {
+			return nil, fmt.Errorf("unknown environment '%s': config does not define any environments", environment)
+		}
+		return nil, fmt.Errorf("unknown environment '%s' (available: %s)", environment, environmentNames(config))
+	}
+
+	overrides := make(map[string]bool)
+	for _, topic := range env.Topics {
+		overrides[topic.Name] = true
+	}
+
+	var topics []TopicConfig
+	for _, topic := range config.Topics {
+		if !overrides[topic.Name] {
+			topics = append(topics, topic)
+		}
+	}
+
+	return append(topics, env.Topics...), nil
+}
+
+// environmentNames returns the sorted, comma-separated environment names
+func environmentNames(config TopicsConfig) string {
+	names := make([]string, 0, len(config.Environments))
+	for name := range config.Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
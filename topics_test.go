@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+func TestValidateResolvedSpecs(t *testing.T) {
+	cases := []struct {
+		name  string
+		specs []kafka.TopicSpecification
+		want  string // substring of the expected error, empty when the specs are valid
+	}{
+		{
+			name: "distinct topics are valid",
+			specs: []kafka.TopicSpecification{
+				{Topic: "orders", NumPartitions: 3, ReplicationFactor: 2},
+				{Topic: "payments", NumPartitions: useBrokerDefault, ReplicationFactor: autoReplicationFactor},
+				{Topic: "audit", NumPartitions: 2, ReplicationFactor: 2, ReplicaAssignment: [][]int32{{1, 2}, {2, 1}}},
+			},
+		},
+		{
+			name: "duplicate topic",
+			specs: []kafka.TopicSpecification{
+				{Topic: "orders", NumPartitions: 3, ReplicationFactor: 2},
+				{Topic: "orders", NumPartitions: 3, ReplicationFactor: 2},
+			},
+			want: "topic 'orders' is defined more than once",
+		},
+		{
+			name: "conflicting partition counts",
+			specs: []kafka.TopicSpecification{
+				{Topic: "orders", NumPartitions: 3, ReplicationFactor: 2},
+				{Topic: "orders", NumPartitions: 6, ReplicationFactor: 2},
+			},
+			want: "conflicting partition counts (3 and 6)",
+		},
+		{
+			name: "conflicting replication factors",
+			specs: []kafka.TopicSpecification{
+				{Topic: "orders", NumPartitions: 3, ReplicationFactor: 2},
+				{Topic: "orders", NumPartitions: 3, ReplicationFactor: 3},
+			},
+			want: "conflicting replication factors (2 and 3)",
+		},
+		{
+			name: "replica assignment shorter than the partitions",
+			specs: []kafka.TopicSpecification{
+				{Topic: "orders", NumPartitions: 3, ReplicationFactor: 2, ReplicaAssignment: [][]int32{{1, 2}, {2, 1}}},
+			},
+			want: "topic 'orders' resolves to 3 partitions but its replica_assignment lists 2",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateResolvedSpecs(tc.specs)
+			switch {
+			case tc.want == "" && err != nil:
+				t.Fatalf("validateResolvedSpecs failed: %v", err)
+			case tc.want != "" && err == nil:
+				t.Fatalf("validateResolvedSpecs succeeded, want an error containing %q", tc.want)
+			case tc.want != "" && !strings.Contains(err.Error(), tc.want):
+				t.Fatalf("validateResolvedSpecs error %q, want it to contain %q", err, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// Verification polls the cluster a few times, since metadata may lag behind the
+// acknowledgment of an admin request
+const (
+	verifyAttempts = 5
+	verifyInterval = time.Second
+)
+
+// verificationSpecs returns the state a sync should have left each applied topic in:
+// created, updated and unchanged topics, without those that failed. The partition
+// count of an update is the current one when its increase was skipped.
+func verificationSpecs(plan SyncPlan, topicSpecs []kafka.TopicSpecification, failedTopics []string) []kafka.TopicSpecification {
+	var specs []kafka.TopicSpecification
+	for _, spec := range plan.ToCreate {
+		if !slices.Contains(failedTopics, spec.Topic) {
+			specs = append(specs, spec)
+		}
+	}
+	for _, update := range plan.ToUpdate {
+		if slices.Contains(failedTopics, update.topic) {
+			continue
+		}
+		spec := update.desired
+		if !update.needsPartitionIncrease {
+			spec.NumPartitions = len(update.current.Partitions)
+		}
+		specs = append(specs, spec)
+	}
+	for _, topic := range plan.Unchanged {
+		specs = append(specs, specByName(topicSpecs, topic))
+	}
+
+	return specs
+}
+
+// verifyTopics re-fetches metadata and configs until every spec's topic exists with
+// the desired partition count and configs, and returns the topics that did not converge
+func (tm *TopicManager) verifyTopics(ctx context.Context, topicSpecs []kafka.TopicSpecification) ([]string, error) {
+	if len(topicSpecs) == 0 {
+		return nil, nil
+	}
+	fmt.Fprintf(messageOut, "🔎 Verifying %d topics against the cluster...\n", len(topicSpecs))
+
+	var unconverged []string
+	for attempt := 1; attempt <= verifyAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(verifyInterval):
+			}
+		}
+
+		existingTopics, err := tm.existingTopicsFor(ctx, topicSpecs)
+		if err != nil {
+			return nil, err
+		}
+		present := make(map[string]kafka.TopicMetadata)
+		for _, spec := range topicSpecs {
+			if metadata, ok := existingTopics[spec.Topic]; ok {
+				present[spec.Topic] = metadata
+			}
+		}
+		configChanges, err := tm.planConfigChanges(ctx, topicSpecs, present)
+		if err != nil {
+			return nil, err
+		}
+
+		var problems []string
+		unconverged = nil
+		for _, spec := range topicSpecs {
+			metadata, exists := present[spec.Topic]
+			switch {
+			case !exists:
+				problems = append(problems, fmt.Sprintf("topic '%s' does not exist", spec.Topic))
+			case spec.NumPartitions != useBrokerDefault && len(metadata.Partitions) != spec.NumPartitions:
+				problems = append(problems, fmt.Sprintf("topic '%s' has %d partitions, expected %d",
+					spec.Topic, len(metadata.Partitions), spec.NumPartitions))
+			case len(configChanges[spec.Topic]) > 0:
+				change := configChanges[spec.Topic][0]
+				expected := change.desired
+				if change.reset {
+					expected = "the default"
+				}
+				problems = append(problems, fmt.Sprintf("topic '%s' has %s=%s, expected %s",
+					spec.Topic, change.name, change.current, expected))
+			default:
+				continue
+			}
+			unconverged = append(unconverged, spec.Topic)
+		}
+
+		if len(unconverged) == 0 {
+			fmt.Fprintf(messageOut, "✅ Verified: all %d topics match the configuration\n", len(topicSpecs))
+			return nil, nil
+		}
+		if attempt == verifyAttempts {
+			for _, problem := range problems {
+				fmt.Fprintf(messageOut, "❌ Not converged: %s\n", problem)
+			}
+		}
+	}
+
+	return unconverged, nil
+}
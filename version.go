@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// version is the tool's version, set at build time with
+// -ldflags "-X main.version=v1.2.3"; go install builds report their module version
+var version = "dev"
+
+// confluentModule is the module path of the Kafka client library
+const confluentModule = "github.com/confluentinc/confluent-kafka-go/v2"
+
+// printVersion writes the tool, Go, confluent-kafka-go and librdkafka versions
+func printVersion(w io.Writer) {
+	toolVersion, clientVersion := version, "unknown"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if toolVersion == "dev" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+			toolVersion = info.Main.Version
+		}
+		for _, dep := range info.Deps {
+			if dep.Path == confluentModule {
+				clientVersion = dep.Version
+			}
+		}
+	}
+	_, librdkafkaVersion := kafka.LibraryVersion()
+
+	fmt.Fprintf(w, "%s %s\n", completionCommand, toolVersion)
+	fmt.Fprintf(w, "  %-20s %s %s/%s\n", "Go:", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(w, "  %-20s %s\n", "confluent-kafka-go:", clientVersion)
+	fmt.Fprintf(w, "  %-20s %s\n", "librdkafka:", librdkafkaVersion)
+}